@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/Azure/azure-kusto-go/kusto"
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -20,10 +21,20 @@ var testAccProvider *schema.Provider
 
 func GetTestClusterConfig() *ClusterConfig {
 	return &ClusterConfig{
-		ClientID:     os.Getenv("ADX_CLIENT_ID"),
-		ClientSecret: os.Getenv("ADX_CLIENT_SECRET"),
-		TenantID:     os.Getenv("ADX_TENANT_ID"),
-		URI:          os.Getenv("ADX_ENDPOINT"),
+		ClientID:          os.Getenv("ADX_CLIENT_ID"),
+		ClientSecret:      os.Getenv("ADX_CLIENT_SECRET"),
+		TenantID:          os.Getenv("ADX_TENANT_ID"),
+		URI:               os.Getenv("ADX_ENDPOINT"),
+		UseMSI:            os.Getenv("ADX_USE_MSI") == "true",
+		MSIClientID:       os.Getenv("ADX_MSI_CLIENT_ID"),
+		UseOIDC:           os.Getenv("ADX_USE_OIDC") == "true",
+		OIDCToken:         os.Getenv("ADX_OIDC_TOKEN"),
+		OIDCTokenFilePath: os.Getenv("AZURE_FEDERATED_TOKEN_FILE"),
+		UseCLI:            os.Getenv("ADX_USE_CLI") == "true",
+
+		ClientCertificatePath:     os.Getenv("ADX_CLIENT_CERTIFICATE_PATH"),
+		ClientCertificate:         os.Getenv("ADX_CLIENT_CERTIFICATE"),
+		ClientCertificatePassword: os.Getenv("ADX_CLIENT_CERTIFICATE_PASSWORD"),
 	}
 }
 
@@ -36,6 +47,8 @@ type ResourceTestContext[T any] struct {
 	Type              string
 	Label             string
 	ReadStatementFunc func(string) (string, error)
+
+	cassette *adxCassette
 }
 
 type ResourceTestContextBuilder[T any] struct {
@@ -43,15 +56,21 @@ type ResourceTestContextBuilder[T any] struct {
 }
 
 func (this *ResourceTestContextBuilder[T]) Build() (*ResourceTestContext[T], error) {
+	if this.context.Test == nil {
+		return nil, fmt.Errorf("Test cannot be nil")
+	}
+	// The cassette has to be started first, replays point the cluster config at the local endpoint
+	cassette, err := startADXCassette(this.context.Test)
+	if err != nil {
+		return nil, err
+	}
+	this.context.cassette = cassette
 	if this.context.Cluster == nil {
 		this.context.Cluster = GetTestClusterConfig()
 	}
 	if this.context.Label == "" {
 		this.context.Label = "test"
 	}
-	if this.context.Test == nil {
-		return nil, fmt.Errorf("Test cannot be nil")
-	}
 	if this.context.DatabaseName == "" {
 		return nil, fmt.Errorf("DatabaseName cannot be empty")
 	}
@@ -59,7 +78,7 @@ func (this *ResourceTestContextBuilder[T]) Build() (*ResourceTestContext[T], err
 		return nil, fmt.Errorf("EntityType cannot be empty")
 	}
 	if this.context.EntityName == "" {
-		this.context.EntityName = acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+		this.context.EntityName = this.context.RandomName()
 	}
 	if this.context.Type == "" {
 		return nil, fmt.Errorf("Type cannot be empty")
@@ -124,6 +143,14 @@ func (this *ResourceTestContext[T]) GetTFName() string {
 	return fmt.Sprintf("%s.%s", this.Type, this.Label)
 }
 
+// RandomName returns a random entity name. Names are recorded in the cassette so that replays issue the same commands.
+func (this *ResourceTestContext[T]) RandomName() string {
+	if this.cassette != nil {
+		return this.cassette.name()
+	}
+	return acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+}
+
 func (this *ResourceTestContext[T]) GetADXClient() (*kusto.Client, error) {
 	return getADXClient(testAccProvider.Meta(), this.Cluster)
 }
@@ -223,20 +250,30 @@ func (this ResourceTestContext[T]) CheckQueryResultSize(query string, expectedRo
 }
 
 func testAccPreCheck(t *testing.T) {
-	if err := os.Getenv("ADX_CLIENT_ID"); err == "" {
-		t.Fatal("ADX_CLIENT_ID must be set for acceptance tests")
+	if err := os.Getenv("ADX_ENDPOINT"); err == "" {
+		t.Fatal("ADX_ENDPOINT must be set for acceptance tests")
 	}
 
-	if err := os.Getenv("ADX_CLIENT_SECRET"); err == "" {
-		t.Fatal("ADX_CLIENT_SECRET must be set for acceptance tests")
+	// Managed identity and the Azure CLI do not need service principal credentials
+	if os.Getenv("ADX_USE_MSI") == "true" || os.Getenv("ADX_USE_CLI") == "true" {
+		return
+	}
+
+	if err := os.Getenv("ADX_CLIENT_ID"); err == "" {
+		t.Fatal("ADX_CLIENT_ID must be set for acceptance tests")
 	}
 
 	if err := os.Getenv("ADX_TENANT_ID"); err == "" {
 		t.Fatal("ADX_TENANT_ID must be set for acceptance tests")
 	}
 
-	if err := os.Getenv("ADX_ENDPOINT"); err == "" {
-		t.Fatal("ADX_ENDPOINT must be set for acceptance tests")
+	// Workload identity federation and certificates replace the client secret
+	if os.Getenv("ADX_USE_OIDC") == "true" || os.Getenv("ADX_CLIENT_CERTIFICATE_PATH") != "" || os.Getenv("ADX_CLIENT_CERTIFICATE") != "" {
+		return
+	}
+
+	if err := os.Getenv("ADX_CLIENT_SECRET"); err == "" {
+		t.Fatal("ADX_CLIENT_SECRET must be set for acceptance tests")
 	}
 }
 
@@ -260,6 +297,6 @@ func GetAccTestPolicyReadStatementFunc() func(string) (string, error) {
 		if err != nil {
 			return "", err
 		}
-		return fmt.Sprintf(".show %s %s policy %s", policyId.EntityType, policyId.Name, policyId.PolicyName), nil
+		return kql.New(".show", policyId.EntityType).Identifier(policyId.Name).Keyword("policy", policyId.PolicyName).String(), nil
 	}
 }
@@ -0,0 +1,408 @@
+package adx
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Azure/azure-kusto-go/kusto"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+)
+
+// Acceptance tests can record their HTTP exchanges with the cluster into a cassette and replay them later
+// without a cluster. ADX_TEST_CASSETTE_MODE selects "record" or "replay", cassettes are stored per test
+// in ADX_TEST_CASSETTE_DIR (testdata/cassettes by default).
+const (
+	cassetteModeEnv    = "ADX_TEST_CASSETTE_MODE"
+	cassetteDirEnv     = "ADX_TEST_CASSETTE_DIR"
+	cassetteModeRecord = "record"
+	cassetteModeReplay = "replay"
+	cassetteRedacted   = "REDACTED"
+)
+
+type Cassette struct {
+	// Random names generated by the test, replayed so that the same commands are issued
+	Names        []string
+	Interactions []CassetteInteraction
+}
+
+type CassetteInteraction struct {
+	Mgmt       bool
+	Database   string
+	CSL        string
+	StatusCode int
+	Body       string
+}
+
+func (i *CassetteInteraction) key() string {
+	return fmt.Sprintf("%t|%s|%s", i.Mgmt, i.Database, i.CSL)
+}
+
+// isControlCommand reports whether the interaction changes the cluster, replays must issue these in the recorded order
+func (i *CassetteInteraction) isControlCommand() bool {
+	return i.Mgmt && !strings.HasPrefix(strings.ToLower(strings.TrimSpace(i.CSL)), ".show")
+}
+
+var cassetteSecretPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)(sig=)[^&'"\s;]+`),
+	regexp.MustCompile(`(?i)(accountkey=)[^;'"\s]+`),
+	regexp.MustCompile(`(?i)(sharedaccesskey=)[^;'"\s]+`),
+	regexp.MustCompile(`(?i)(password=)[^;'"\s]+`),
+	regexp.MustCompile(`(?i)(bearer\s+)[\w\-.~+/]+=*`),
+	// storage connection strings may carry the account key after the container uri
+	regexp.MustCompile(`(?i)(\.core\.windows\.net/[^;'"\s]*;)[^'"\s]+`),
+}
+
+// scrubCassetteSecrets removes tokens and credentials so that cassettes can be committed
+func scrubCassetteSecrets(text string) string {
+	for _, pattern := range cassetteSecretPatterns {
+		text = pattern.ReplaceAllString(text, "${1}"+cassetteRedacted)
+	}
+	for _, env := range []string{"ADX_CLIENT_SECRET", "ADX_CLIENT_CERTIFICATE_PASSWORD", "ADX_OIDC_TOKEN"} {
+		if secret := os.Getenv(env); secret != "" {
+			text = strings.ReplaceAll(text, secret, cassetteRedacted)
+		}
+	}
+	return text
+}
+
+type adxCassette struct {
+	test *testing.T
+	path string
+	mode string
+
+	mu        sync.Mutex
+	data      Cassette
+	nameIndex int
+
+	// replay state
+	used       []bool
+	epochs     []int
+	controls   []int
+	replayed   int
+	lastServed map[string]int
+}
+
+var (
+	activeCassetteMU sync.Mutex
+	activeCassette   *adxCassette
+
+	cassetteServerOnce sync.Once
+	cassetteServer     *httptest.Server
+
+	// cassetteTransport is the transport recordings are made with
+	cassetteTransport http.RoundTripper = http.DefaultTransport
+)
+
+func getActiveCassette() *adxCassette {
+	activeCassetteMU.Lock()
+	defer activeCassetteMU.Unlock()
+	return activeCassette
+}
+
+func setActiveCassette(cassette *adxCassette) {
+	activeCassetteMU.Lock()
+	defer activeCassetteMU.Unlock()
+	activeCassette = cassette
+}
+
+func getCassettePath(t *testing.T) string {
+	dir := os.Getenv(cassetteDirEnv)
+	if dir == "" {
+		dir = filepath.Join("testdata", "cassettes")
+	}
+	name := regexp.MustCompile(`[^\w\-.]+`).ReplaceAllString(t.Name(), "_")
+	return filepath.Join(dir, name+".json")
+}
+
+// startADXCassette records or replays the cluster traffic of the test according to ADX_TEST_CASSETTE_MODE.
+// It returns nil when the test runs against a live cluster without a cassette.
+func startADXCassette(t *testing.T) (*adxCassette, error) {
+	mode := os.Getenv(cassetteModeEnv)
+	if mode == "" {
+		return nil, nil
+	}
+	if mode != cassetteModeRecord && mode != cassetteModeReplay {
+		return nil, fmt.Errorf("%s must be %q or %q, got %q", cassetteModeEnv, cassetteModeRecord, cassetteModeReplay, mode)
+	}
+	if active := getActiveCassette(); active != nil && active.test == t {
+		return active, nil
+	}
+	return newADXCassette(t, getCassettePath(t), mode)
+}
+
+func newADXCassette(t *testing.T, path string, mode string) (*adxCassette, error) {
+	cassette := &adxCassette{
+		test:       t,
+		path:       path,
+		mode:       mode,
+		lastServed: make(map[string]int),
+	}
+
+	previousKustoClient := newKustoClient
+	switch mode {
+	case cassetteModeRecord:
+		newKustoClient = func(endpoint string, auth kusto.Authorization, options ...kusto.Option) (*kusto.Client, error) {
+			options = append(options, kusto.WithHttpClient(&http.Client{Transport: &cassetteRecorder{base: cassetteTransport}}))
+			return previousKustoClient(endpoint, auth, options...)
+		}
+	case cassetteModeReplay:
+		if err := cassette.load(); err != nil {
+			return nil, err
+		}
+		server := getCassetteServer()
+		// Credentials are never used when replaying, they only need to satisfy the provider and pre-checks
+		t.Setenv("ADX_ENDPOINT", server.URL)
+		t.Setenv("ADX_CLIENT_ID", cassetteRedacted)
+		t.Setenv("ADX_CLIENT_SECRET", cassetteRedacted)
+		t.Setenv("ADX_TENANT_ID", cassetteRedacted)
+		newKustoClient = func(endpoint string, _ kusto.Authorization, options ...kusto.Option) (*kusto.Client, error) {
+			options = append(options, kusto.WithHttpClient(server.Client()))
+			return previousKustoClient(endpoint, kusto.Authorization{Authorizer: autorest.NullAuthorizer{}}, options...)
+		}
+	}
+
+	setActiveCassette(cassette)
+	t.Cleanup(func() {
+		setActiveCassette(nil)
+		newKustoClient = previousKustoClient
+		cassette.finish()
+	})
+	return cassette, nil
+}
+
+func (c *adxCassette) load() error {
+	content, err := os.ReadFile(c.path)
+	if err != nil {
+		return fmt.Errorf("could not read cassette (record it with %s=%s): %+v", cassetteModeEnv, cassetteModeRecord, err)
+	}
+	if err := json.Unmarshal(content, &c.data); err != nil {
+		return fmt.Errorf("could not parse cassette %s: %+v", c.path, err)
+	}
+
+	c.used = make([]bool, len(c.data.Interactions))
+	c.epochs = make([]int, len(c.data.Interactions))
+	for i := range c.data.Interactions {
+		c.epochs[i] = len(c.controls)
+		if c.data.Interactions[i].isControlCommand() {
+			c.controls = append(c.controls, i)
+		}
+	}
+	return nil
+}
+
+func (c *adxCassette) finish() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch c.mode {
+	case cassetteModeRecord:
+		if c.test.Failed() {
+			c.test.Logf("not writing cassette %s for a failed test", c.path)
+			return
+		}
+		content, err := json.MarshalIndent(c.data, "", "  ")
+		if err != nil {
+			c.test.Errorf("could not serialize cassette %s: %+v", c.path, err)
+			return
+		}
+		if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+			c.test.Errorf("could not create cassette directory: %+v", err)
+			return
+		}
+		if err := os.WriteFile(c.path, append(content, '\n'), 0644); err != nil {
+			c.test.Errorf("could not write cassette %s: %+v", c.path, err)
+		}
+	case cassetteModeReplay:
+		for _, i := range c.controls {
+			if !c.used[i] {
+				c.test.Errorf("cassette %s: recorded control command was never issued: %s", c.path, c.data.Interactions[i].CSL)
+			}
+		}
+	}
+}
+
+// name returns a random name when recording and the recorded one when replaying
+func (c *adxCassette) name() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.mode == cassetteModeReplay {
+		if c.nameIndex >= len(c.data.Names) {
+			c.test.Errorf("cassette %s: test generated more random names than were recorded", c.path)
+			return acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+		}
+		c.nameIndex++
+		return c.data.Names[c.nameIndex-1]
+	}
+
+	name := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	c.data.Names = append(c.data.Names, name)
+	return name
+}
+
+func (c *adxCassette) record(interaction CassetteInteraction) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data.Interactions = append(c.data.Interactions, interaction)
+}
+
+// replay finds the recorded response for a request. Control commands are matched in the recorded order, anything
+// else is answered with a response recorded for the same command after the same number of control commands.
+func (c *adxCassette) replay(request CassetteInteraction) (*CassetteInteraction, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := request.key()
+	if request.isControlCommand() {
+		for _, i := range c.controls {
+			if c.used[i] {
+				continue
+			}
+			if c.data.Interactions[i].key() != key {
+				return nil, fmt.Errorf("control command does not match cassette %s\n got: %s\nwant: %s", c.path, request.CSL, c.data.Interactions[i].CSL)
+			}
+			c.used[i] = true
+			c.replayed++
+			return &c.data.Interactions[i], nil
+		}
+		return nil, fmt.Errorf("control command was not recorded in cassette %s: %s", c.path, request.CSL)
+	}
+
+	for i := range c.data.Interactions {
+		if !c.used[i] && c.epochs[i] == c.replayed && c.data.Interactions[i].key() == key {
+			c.used[i] = true
+			c.lastServed[key] = i
+			return &c.data.Interactions[i], nil
+		}
+	}
+	// Terraform may read an entity more often than it did when recording, the state has not changed since
+	if i, ok := c.lastServed[key]; ok && c.epochs[i] == c.replayed {
+		return &c.data.Interactions[i], nil
+	}
+	return nil, fmt.Errorf("command was not recorded in cassette %s after %d control commands: %s", c.path, c.replayed, request.CSL)
+}
+
+type cassetteRequest struct {
+	DB  string `json:"db"`
+	CSL string `json:"csl"`
+}
+
+func readCassetteRequest(r *http.Request) (CassetteInteraction, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return CassetteInteraction{}, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	var request cassetteRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		return CassetteInteraction{}, fmt.Errorf("could not parse kusto request: %+v", err)
+	}
+	return CassetteInteraction{
+		Mgmt:     strings.HasSuffix(r.URL.Path, "/mgmt"),
+		Database: request.DB,
+		CSL:      scrubCassetteSecrets(request.CSL),
+	}, nil
+}
+
+// cassetteRecorder captures the exchanges of the active cassette, responses are stored uncompressed
+type cassetteRecorder struct {
+	base http.RoundTripper
+}
+
+func (r *cassetteRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	cassette := getActiveCassette()
+	if cassette == nil || cassette.mode != cassetteModeRecord {
+		return r.base.RoundTrip(req)
+	}
+
+	interaction, err := readCassetteRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var body io.Reader = resp.Body
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("could not decompress kusto response: %+v", err)
+		}
+		body = gz
+	}
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction.StatusCode = resp.StatusCode
+	interaction.Body = scrubCassetteSecrets(string(content))
+	cassette.record(interaction)
+
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = int64(len(content))
+	resp.Body = io.NopCloser(bytes.NewReader(content))
+	return resp, nil
+}
+
+// getCassetteServer returns the process wide endpoint serving the active cassette. Kusto clients are cached by
+// cluster config, sharing one endpoint keeps cached clients valid across tests.
+func getCassetteServer() *httptest.Server {
+	cassetteServerOnce.Do(func() {
+		cassetteServer = httptest.NewTLSServer(http.HandlerFunc(serveCassette))
+	})
+	return cassetteServer
+}
+
+func serveCassette(w http.ResponseWriter, r *http.Request) {
+	cassette := getActiveCassette()
+	if cassette == nil {
+		writeCassetteError(w, fmt.Errorf("no cassette is being replayed"))
+		return
+	}
+
+	request, err := readCassetteRequest(r)
+	if err != nil {
+		writeCassetteError(w, err)
+		return
+	}
+	interaction, err := cassette.replay(request)
+	if err != nil {
+		cassette.test.Errorf("%+v", err)
+		writeCassetteError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(interaction.StatusCode)
+	io.WriteString(w, interaction.Body)
+}
+
+func writeCassetteError(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":       "BadRequest_CassetteMismatch",
+			"message":    err.Error(),
+			"@permanent": true,
+		},
+	})
+}
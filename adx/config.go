@@ -3,17 +3,32 @@ package adx
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/Azure/azure-kusto-go/kusto"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
 
 type Config struct {
-	ClientID     string
-	ClientSecret string
-	TenantID     string
-	Endpoint     string
-	LazyInit     bool
+	ClientID          string
+	ClientSecret      string
+	TenantID          string
+	Endpoint          string
+	LazyInit          bool
+	UseMSI            bool
+	MSIClientID       string
+	UseOIDC           bool
+	OIDCToken         string
+	OIDCTokenFilePath string
+	UseCLI            bool
+
+	ClientCertificatePath     string
+	ClientCertificate         string
+	ClientCertificatePassword string
+
+	MaxRetries            int
+	RetryMaxDelay         time.Duration
+	MaxConcurrentCommands int
 }
 
 type Meta struct {
@@ -21,6 +36,16 @@ type Meta struct {
 	KustoClientsMapMU    sync.RWMutex
 	DefaultClusterConfig *ClusterConfig
 	StopContext          context.Context
+
+	// MaxRetries and RetryMaxDelay bound the retries of throttled or transiently failing commands
+	MaxRetries    int
+	RetryMaxDelay time.Duration
+
+	// MaxConcurrentCommands limits the commands in flight against each cluster, the semaphores are keyed
+	// by cluster URI and shared by all clients of a cluster. Zero means no limit.
+	MaxConcurrentCommands int
+	CommandSemaphores     map[string]chan struct{}
+	CommandSemaphoresMU   sync.Mutex
 }
 
 func (c *Config) Client(userAgent string) (*Meta, diag.Diagnostics) {
@@ -30,6 +55,11 @@ func (c *Config) Client(userAgent string) (*Meta, diag.Diagnostics) {
 		StopContext:          context.Background(),
 		DefaultClusterConfig: clusterConfig,
 		KustoClientsMap:      make(map[string]*kusto.Client),
+		MaxRetries:           c.MaxRetries,
+		RetryMaxDelay:        c.RetryMaxDelay,
+
+		MaxConcurrentCommands: c.MaxConcurrentCommands,
+		CommandSemaphores:     make(map[string]chan struct{}),
 	}
 
 	// Not returning an error here on missing values because the user can specify "missing" config for each resource
@@ -49,9 +79,19 @@ func (c *Config) Client(userAgent string) (*Meta, diag.Diagnostics) {
 
 func providerConfigToClusterConfig(config *Config) *ClusterConfig {
 	return &ClusterConfig{
-		ClientID:     config.ClientID,
-		ClientSecret: config.ClientSecret,
-		TenantID:     config.TenantID,
-		URI:          config.Endpoint,
+		ClientID:          config.ClientID,
+		ClientSecret:      config.ClientSecret,
+		TenantID:          config.TenantID,
+		URI:               config.Endpoint,
+		UseMSI:            config.UseMSI,
+		MSIClientID:       config.MSIClientID,
+		UseOIDC:           config.UseOIDC,
+		OIDCToken:         config.OIDCToken,
+		OIDCTokenFilePath: config.OIDCTokenFilePath,
+		UseCLI:            config.UseCLI,
+
+		ClientCertificatePath:     config.ClientCertificatePath,
+		ClientCertificate:         config.ClientCertificate,
+		ClientCertificatePassword: config.ClientCertificatePassword,
 	}
 }
@@ -0,0 +1,302 @@
+package adx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
+	"github.com/favoretti/terraform-provider-adx/adx/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type DatabaseSchemaJSON struct {
+	DatabaseSchema string
+}
+
+type databaseSchemaObject struct {
+	Databases map[string]databaseSchemaDatabase `json:"Databases"`
+}
+
+type databaseSchemaDatabase struct {
+	Name              string                                    `json:"Name"`
+	Tables            map[string]databaseSchemaTable            `json:"Tables"`
+	ExternalTables    map[string]databaseSchemaTable            `json:"ExternalTables"`
+	MaterializedViews map[string]databaseSchemaMaterializedView `json:"MaterializedViews"`
+	Functions         map[string]databaseSchemaFunction         `json:"Functions"`
+}
+
+type databaseSchemaTable struct {
+	Name           string                 `json:"Name"`
+	Folder         string                 `json:"Folder"`
+	DocString      string                 `json:"DocString"`
+	OrderedColumns []databaseSchemaColumn `json:"OrderedColumns"`
+}
+
+type databaseSchemaMaterializedView struct {
+	databaseSchemaTable
+	SourceTable string `json:"SourceTable"`
+	Query       string `json:"Query"`
+}
+
+type databaseSchemaColumn struct {
+	Name      string `json:"Name"`
+	CslType   string `json:"CslType"`
+	DocString string `json:"DocString"`
+}
+
+type databaseSchemaFunction struct {
+	Name            string                    `json:"Name"`
+	Folder          string                    `json:"Folder"`
+	DocString       string                    `json:"DocString"`
+	Body            string                    `json:"Body"`
+	InputParameters []databaseSchemaParameter `json:"InputParameters"`
+}
+
+type databaseSchemaParameter struct {
+	Name            string                 `json:"Name"`
+	CslType         string                 `json:"CslType"`
+	CslDefaultValue *string                `json:"CslDefaultValue"`
+	Columns         []databaseSchemaColumn `json:"Columns"`
+}
+
+func dataSourceADXDatabaseSchema() *schema.Resource {
+	computedString := func() *schema.Schema { return &schema.Schema{Type: schema.TypeString, Computed: true} }
+	columnSchema := func() *schema.Schema {
+		return &schema.Schema{
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name":      computedString(),
+					"type":      computedString(),
+					"docstring": computedString(),
+				},
+			},
+		}
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceADXDatabaseSchemaRead,
+
+		Schema: map[string]*schema.Schema{
+			"cluster": getClusterConfigInputSchema(),
+			"database_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+			},
+
+			"tables": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name":      computedString(),
+						"folder":    computedString(),
+						"docstring": computedString(),
+						"column":    columnSchema(),
+					},
+				},
+			},
+
+			"external_tables": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name":      computedString(),
+						"folder":    computedString(),
+						"docstring": computedString(),
+						"column":    columnSchema(),
+					},
+				},
+			},
+
+			"materialized_views": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name":         computedString(),
+						"folder":       computedString(),
+						"docstring":    computedString(),
+						"source_table": computedString(),
+						"query":        computedString(),
+						"column":       columnSchema(),
+					},
+				},
+			},
+
+			"functions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name":      computedString(),
+						"folder":    computedString(),
+						"docstring": computedString(),
+						"body":      computedString(),
+						"parameter": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name":          computedString(),
+									"type":          computedString(),
+									"default_value": computedString(),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceADXDatabaseSchemaRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clusterConfig := getAndExpandClusterConfigWithDefaults(ctx, d, meta)
+	databaseName := d.Get("database_name").(string)
+
+	showStatement := kql.New(".show", "database").Identifier(databaseName).Keyword("schema", "as", "json").String()
+	resultSet, err := queryADXMgmtAndParse[DatabaseSchemaJSON](ctx, meta, clusterConfig, databaseName, showStatement)
+	if err != nil {
+		return diag.Errorf("error reading schema of Database %q: %+v", databaseName, err)
+	}
+	if len(resultSet) == 0 {
+		return diag.Errorf("error reading schema of Database %q: no schema returned", databaseName)
+	}
+
+	var schemaObject databaseSchemaObject
+	if err := json.Unmarshal([]byte(resultSet[0].DatabaseSchema), &schemaObject); err != nil {
+		return diag.Errorf("error parsing schema of Database %q: %+v", databaseName, err)
+	}
+
+	database, err := findDatabaseSchema(ctx, meta, clusterConfig, databaseName, schemaObject.Databases)
+	if err != nil {
+		return diag.Errorf("%+v", err)
+	}
+
+	tables := make([]interface{}, 0, len(database.Tables))
+	for _, name := range sortedMapKeys(database.Tables) {
+		tables = append(tables, flattenDatabaseSchemaTable(database.Tables[name]))
+	}
+
+	externalTables := make([]interface{}, 0, len(database.ExternalTables))
+	for _, name := range sortedMapKeys(database.ExternalTables) {
+		externalTables = append(externalTables, flattenDatabaseSchemaTable(database.ExternalTables[name]))
+	}
+
+	materializedViews := make([]interface{}, 0, len(database.MaterializedViews))
+	for _, name := range sortedMapKeys(database.MaterializedViews) {
+		view := database.MaterializedViews[name]
+		flattened := flattenDatabaseSchemaTable(view.databaseSchemaTable)
+		flattened["source_table"] = view.SourceTable
+		flattened["query"] = view.Query
+		materializedViews = append(materializedViews, flattened)
+	}
+
+	functions := make([]interface{}, 0, len(database.Functions))
+	for _, name := range sortedMapKeys(database.Functions) {
+		functions = append(functions, flattenDatabaseSchemaFunction(database.Functions[name]))
+	}
+
+	d.SetId(buildADXResourceId(clusterConfig.URI, databaseName, "schema"))
+	d.Set("tables", tables)
+	d.Set("external_tables", externalTables)
+	d.Set("materialized_views", materializedViews)
+	d.Set("functions", functions)
+
+	return nil
+}
+
+// findDatabaseSchema picks the database from the schema. Fabric KQL databases are keyed by a UUID rather than
+// their name, those are matched by resolving their name.
+func findDatabaseSchema(ctx context.Context, meta interface{}, clusterConfig *ClusterConfig, databaseName string, databases map[string]databaseSchemaDatabase) (*databaseSchemaDatabase, error) {
+	for key, database := range databases {
+		if strings.EqualFold(key, databaseName) {
+			return &database, nil
+		}
+	}
+
+	for key, database := range databases {
+		if !isUUID(key) {
+			continue
+		}
+		resolvedName, err := resolveDatabaseName(ctx, meta, clusterConfig, databaseName, key)
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(resolvedName, databaseName) {
+			return &database, nil
+		}
+	}
+
+	return nil, fmt.Errorf("schema of Database %q was not found in the result", databaseName)
+}
+
+func flattenDatabaseSchemaTable(table databaseSchemaTable) map[string]interface{} {
+	return map[string]interface{}{
+		"name":      table.Name,
+		"folder":    table.Folder,
+		"docstring": table.DocString,
+		"column":    flattenDatabaseSchemaColumns(table.OrderedColumns),
+	}
+}
+
+func flattenDatabaseSchemaColumns(columns []databaseSchemaColumn) []interface{} {
+	flattened := make([]interface{}, 0, len(columns))
+	for _, column := range columns {
+		flattened = append(flattened, map[string]interface{}{
+			"name":      column.Name,
+			"type":      column.CslType,
+			"docstring": column.DocString,
+		})
+	}
+	return flattened
+}
+
+func flattenDatabaseSchemaFunction(function databaseSchemaFunction) map[string]interface{} {
+	parameters := make([]interface{}, 0, len(function.InputParameters))
+	for _, parameter := range function.InputParameters {
+		parameterType := parameter.CslType
+		// tabular parameters have columns instead of a type, they are given as a schema such as (Name:string)
+		if parameterType == "" && len(parameter.Columns) > 0 {
+			var columns []string
+			for _, column := range parameter.Columns {
+				columns = append(columns, kql.Identifier(column.Name)+":"+column.CslType)
+			}
+			parameterType = "(" + strings.Join(columns, ", ") + ")"
+		}
+		defaultValue := ""
+		if parameter.CslDefaultValue != nil {
+			defaultValue = *parameter.CslDefaultValue
+		}
+		parameters = append(parameters, map[string]interface{}{
+			"name":          parameter.Name,
+			"type":          parameterType,
+			"default_value": defaultValue,
+		})
+	}
+
+	return map[string]interface{}{
+		"name":      function.Name,
+		"folder":    function.Folder,
+		"docstring": function.DocString,
+		"body":      function.Body,
+		"parameter": parameters,
+	}
+}
+
+func sortedMapKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,75 @@
+package adx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXDatabaseSchemaDataSource_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	db := f.database("fake_db")
+	events := db.addTable("Events", "Timestamp:datetime,Name:string")
+	events.folder = "telemetry"
+	events.docString = "Raw events"
+	db.addTable("Audit", "Id:long")
+	db.addExternalTable("Archive", "Id:long,Payload:dynamic")
+	view := db.addMaterializedView("EventsByName", "Events", "Events | summarize count() by Name")
+	view.folder = "views"
+	fn := db.addFunction("EventsSince", "(since:datetime, name:string = 'all')", "{ Events | where Timestamp > since }")
+	fn.docString = "Events after a time"
+	r := newFakeResource(t, f, dataSourceADXDatabaseSchema())
+
+	state, diags := r.read(map[string]interface{}{
+		"database_name": "fake_db",
+	})
+	assert.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|schema", f.endpoint()), state.ID)
+
+	assert.Equal(t, "2", state.Attributes["tables.#"])
+	assert.Equal(t, "Audit", state.Attributes["tables.0.name"])
+	assert.Equal(t, "Events", state.Attributes["tables.1.name"])
+	assert.Equal(t, "telemetry", state.Attributes["tables.1.folder"])
+	assert.Equal(t, "Raw events", state.Attributes["tables.1.docstring"])
+	assert.Equal(t, "2", state.Attributes["tables.1.column.#"])
+	assert.Equal(t, "Timestamp", state.Attributes["tables.1.column.0.name"])
+	assert.Equal(t, "datetime", state.Attributes["tables.1.column.0.type"])
+
+	assert.Equal(t, "1", state.Attributes["external_tables.#"])
+	assert.Equal(t, "Archive", state.Attributes["external_tables.0.name"])
+	assert.Equal(t, "dynamic", state.Attributes["external_tables.0.column.1.type"])
+
+	assert.Equal(t, "1", state.Attributes["materialized_views.#"])
+	assert.Equal(t, "EventsByName", state.Attributes["materialized_views.0.name"])
+	assert.Equal(t, "views", state.Attributes["materialized_views.0.folder"])
+	assert.Equal(t, "Events", state.Attributes["materialized_views.0.source_table"])
+	assert.Equal(t, "Events | summarize count() by Name", state.Attributes["materialized_views.0.query"])
+
+	assert.Equal(t, "1", state.Attributes["functions.#"])
+	assert.Equal(t, "EventsSince", state.Attributes["functions.0.name"])
+	assert.Equal(t, "Events after a time", state.Attributes["functions.0.docstring"])
+	assert.Equal(t, "{ Events | where Timestamp > since }", state.Attributes["functions.0.body"])
+	assert.Equal(t, "2", state.Attributes["functions.0.parameter.#"])
+	assert.Equal(t, "since", state.Attributes["functions.0.parameter.0.name"])
+	assert.Equal(t, "datetime", state.Attributes["functions.0.parameter.0.type"])
+	assert.Equal(t, "", state.Attributes["functions.0.parameter.0.default_value"])
+	assert.Equal(t, "'all'", state.Attributes["functions.0.parameter.1.default_value"])
+	assert.Empty(t, f.controlCommands())
+}
+
+func TestFakeADXDatabaseSchemaDataSource_fabricDatabase(t *testing.T) {
+	f := newFakeKusto(t)
+	db := f.database("fake_db")
+	db.fabricID = "8c1b7a3e-2f4d-4e6a-9b0c-1d2e3f4a5b6c"
+	db.addTable("Events", "Timestamp:datetime")
+	r := newFakeResource(t, f, dataSourceADXDatabaseSchema())
+
+	state, diags := r.read(map[string]interface{}{
+		"database_name": "fake_db",
+	})
+	assert.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|schema", f.endpoint()), state.ID)
+	assert.Equal(t, "1", state.Attributes["tables.#"])
+	assert.Equal(t, "Events", state.Attributes["tables.0.name"])
+}
@@ -0,0 +1,89 @@
+package adx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
+	"github.com/favoretti/terraform-provider-adx/adx/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceADXFunction() *schema.Resource {
+	functionSchema := functionDataSourceSchema()
+	functionSchema["cluster"] = getClusterConfigInputSchema()
+	functionSchema["database_name"] = &schema.Schema{
+		Type:             schema.TypeString,
+		Required:         true,
+		ValidateDiagFunc: validate.StringIsNotEmpty,
+	}
+	functionSchema["name"] = &schema.Schema{
+		Type:             schema.TypeString,
+		Required:         true,
+		ValidateDiagFunc: validate.StringIsNotEmpty,
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceADXFunctionRead,
+		Schema:      functionSchema,
+	}
+}
+
+// functionDataSourceSchema holds the computed attributes of a function, shared by the adx_function and adx_functions data sources
+func functionDataSourceSchema() map[string]*schema.Schema {
+	computedString := func() *schema.Schema { return &schema.Schema{Type: schema.TypeString, Computed: true} }
+
+	return map[string]*schema.Schema{
+		"name":       computedString(),
+		"parameters": computedString(),
+		"body":       computedString(),
+		"folder":     computedString(),
+		"docstring":  computedString(),
+	}
+}
+
+func dataSourceADXFunctionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clusterConfig := getAndExpandClusterConfigWithDefaults(ctx, d, meta)
+	databaseName := d.Get("database_name").(string)
+	name := d.Get("name").(string)
+
+	functions, err := readADXFunctions(ctx, meta, clusterConfig, databaseName, name)
+	if err != nil {
+		return diag.Errorf("%+v", err)
+	}
+	if len(functions) == 0 {
+		return diag.Errorf("Function %q (Database %q) was not found", name, databaseName)
+	}
+
+	d.SetId(buildADXResourceId(clusterConfig.URI, databaseName, "function", functions[0].Name))
+	for k, v := range flattenADXFunction(functions[0]) {
+		d.Set(k, v)
+	}
+
+	return nil
+}
+
+// readADXFunctions reads the given function, or all functions of the database when the name is empty
+func readADXFunctions(ctx context.Context, meta interface{}, clusterConfig *ClusterConfig, databaseName string, name string) ([]ADXFunction, error) {
+	showStatement := kql.New(".show", "functions")
+	if name != "" {
+		showStatement.Keyword("|", "where", "Name", "==").StringLiteral(name)
+	}
+
+	functions, err := queryADXMgmtAndParse[ADXFunction](ctx, meta, clusterConfig, databaseName, showStatement.String())
+	if err != nil {
+		return nil, fmt.Errorf("error reading functions (Database %q): %+v", databaseName, err)
+	}
+	return functions, nil
+}
+
+func flattenADXFunction(function ADXFunction) map[string]interface{} {
+	return map[string]interface{}{
+		"name":       function.Name,
+		"parameters": function.Parameters,
+		"body":       function.Body,
+		"folder":     function.Folder,
+		"docstring":  function.DocString,
+	}
+}
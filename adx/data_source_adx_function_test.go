@@ -0,0 +1,40 @@
+package adx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXFunctionDataSource_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	fn := f.database("fake_db").addFunction("EventsSince", "(since:datetime)", "{ Events | where Timestamp > since }")
+	fn.folder = "shared"
+	fn.docString = "Events after a time"
+	r := newFakeResource(t, f, dataSourceADXFunction())
+
+	state, diags := r.read(map[string]interface{}{
+		"database_name": "fake_db",
+		"name":          "EventsSince",
+	})
+	assert.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|function|EventsSince", f.endpoint()), state.ID)
+	assert.Equal(t, "(since:datetime)", state.Attributes["parameters"])
+	assert.Equal(t, "{ Events | where Timestamp > since }", state.Attributes["body"])
+	assert.Equal(t, "shared", state.Attributes["folder"])
+	assert.Equal(t, "Events after a time", state.Attributes["docstring"])
+	assert.Empty(t, f.controlCommands())
+}
+
+func TestFakeADXFunctionDataSource_notFound(t *testing.T) {
+	f := newFakeKusto(t)
+	r := newFakeResource(t, f, dataSourceADXFunction())
+
+	_, diags := r.read(map[string]interface{}{
+		"database_name": "fake_db",
+		"name":          "Missing",
+	})
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, `Function "Missing" (Database "fake_db") was not found`)
+}
@@ -0,0 +1,90 @@
+package adx
+
+import (
+	"context"
+	"encoding/hex"
+	"regexp"
+
+	"github.com/favoretti/terraform-provider-adx/adx/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceADXFunctions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceADXFunctionsRead,
+
+		Schema: map[string]*schema.Schema{
+			"cluster": getClusterConfigInputSchema(),
+			"database_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+			},
+
+			"folder": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the functions in this folder",
+			},
+
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "Only return the functions whose name matches this regular expression",
+			},
+
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"functions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: functionDataSourceSchema(),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceADXFunctionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clusterConfig := getAndExpandClusterConfigWithDefaults(ctx, d, meta)
+	databaseName := d.Get("database_name").(string)
+	folder, filterFolder := d.GetOk("folder")
+	nameRegex := d.Get("name_regex").(string)
+
+	var namePattern *regexp.Regexp
+	if nameRegex != "" {
+		namePattern = regexp.MustCompile(nameRegex)
+	}
+
+	functions, err := readADXFunctions(ctx, meta, clusterConfig, databaseName, "")
+	if err != nil {
+		return diag.Errorf("%+v", err)
+	}
+
+	names := make([]string, 0)
+	flattened := make([]interface{}, 0)
+	for _, function := range functions {
+		if filterFolder && function.Folder != folder.(string) {
+			continue
+		}
+		if namePattern != nil && !namePattern.MatchString(function.Name) {
+			continue
+		}
+		names = append(names, function.Name)
+		flattened = append(flattened, flattenADXFunction(function))
+	}
+
+	d.SetId(buildADXResourceId(clusterConfig.URI, databaseName, "functions", hex.EncodeToString(hashObjects(folder, nameRegex))))
+	d.Set("names", names)
+	d.Set("functions", flattened)
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package adx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXFunctionsDataSource_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	db := f.database("fake_db")
+	db.addFunction("EventsSince", "(since:datetime)", "{ Events | where Timestamp > since }").folder = "shared"
+	db.addFunction("EventsToday", "()", "{ EventsSince(startofday(now())) }").folder = "shared"
+	db.addFunction("Cleanup", "()", "{ print 1 }").folder = "internal"
+	r := newFakeResource(t, f, dataSourceADXFunctions())
+
+	state, diags := r.read(map[string]interface{}{
+		"database_name": "fake_db",
+	})
+	assert.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, "3", state.Attributes["names.#"])
+
+	state, diags = r.read(map[string]interface{}{
+		"database_name": "fake_db",
+		"folder":        "shared",
+	})
+	assert.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, "2", state.Attributes["names.#"])
+	assert.Equal(t, "EventsSince", state.Attributes["names.0"])
+	assert.Equal(t, "EventsToday", state.Attributes["names.1"])
+	assert.Equal(t, "(since:datetime)", state.Attributes["functions.0.parameters"])
+
+	state, diags = r.read(map[string]interface{}{
+		"database_name": "fake_db",
+		"name_regex":    "Today$",
+	})
+	assert.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, "1", state.Attributes["names.#"])
+	assert.Equal(t, "{ EventsSince(startofday(now())) }", state.Attributes["functions.0.body"])
+}
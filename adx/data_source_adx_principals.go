@@ -0,0 +1,150 @@
+package adx
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
+	"github.com/favoretti/terraform-provider-adx/adx/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceADXPrincipals() *schema.Resource {
+	computedString := func() *schema.Schema { return &schema.Schema{Type: schema.TypeString, Computed: true} }
+
+	return &schema.Resource{
+		ReadContext: dataSourceADXPrincipalsRead,
+
+		Schema: map[string]*schema.Schema{
+			"cluster": getClusterConfigInputSchema(),
+			"database_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+			},
+
+			"include_tables": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Also list the principals granted on the tables of the database",
+			},
+
+			"table_names": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Only list the principals of these tables, all tables are listed when omitted",
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validate.StringIsNotEmpty,
+				},
+			},
+
+			"role": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(databasePrincipalRoles, false),
+				Description:  "Only list the principals holding this role, e.g. admins",
+			},
+
+			"principal_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the principals of this type, e.g. AAD User, AAD Group or AAD Application",
+			},
+
+			"principals": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"entity_type":    computedString(),
+						"entity_name":    computedString(),
+						"role":           computedString(),
+						"principal_type": computedString(),
+						"display_name":   computedString(),
+						"object_id":      computedString(),
+						"fqn":            computedString(),
+						"notes":          computedString(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceADXPrincipalsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clusterConfig := getAndExpandClusterConfigWithDefaults(ctx, d, meta)
+	databaseName := d.Get("database_name").(string)
+	includeTables := d.Get("include_tables").(bool)
+	tableNames := expandStringSet(d.Get("table_names").(*schema.Set))
+	role := d.Get("role").(string)
+	principalType := d.Get("principal_type").(string)
+
+	matches := func(p TablePrincipal) bool {
+		return (role == "" || matchesRole(p, role)) && (principalType == "" || strings.EqualFold(p.PrincipalType, principalType))
+	}
+
+	showStatement := kql.New(".show", "database").Identifier(databaseName).Keyword("principals").String()
+	databasePrincipals, err := queryADXMgmtAndParse[TablePrincipal](ctx, meta, clusterConfig, databaseName, showStatement)
+	if err != nil {
+		return diag.Errorf("error reading principals of Database %q: %+v", databaseName, err)
+	}
+
+	principals := make([]interface{}, 0)
+	for _, p := range databasePrincipals {
+		if matches(p) {
+			principals = append(principals, flattenADXPrincipal(p, "database", databaseName))
+		}
+	}
+
+	// the id reflects the configured tables, before they default to all tables of the database
+	id := buildADXResourceId(clusterConfig.URI, databaseName, "principals", hex.EncodeToString(hashObjects(includeTables, tableNames, role, principalType)))
+
+	if includeTables {
+		if len(tableNames) == 0 {
+			details, err := readADXTablesDetails(ctx, meta, clusterConfig, databaseName)
+			if err != nil {
+				return diag.Errorf("%+v", err)
+			}
+			for _, table := range details {
+				tableNames = append(tableNames, table.TableName)
+			}
+		}
+
+		for _, tableName := range tableNames {
+			showStatement := kql.New(".show", "table").Identifier(tableName).Keyword("principals").String()
+			tablePrincipals, err := queryADXMgmtAndParse[TablePrincipal](ctx, meta, clusterConfig, databaseName, showStatement)
+			if err != nil {
+				return diag.Errorf("error reading principals of Table %q (Database %q): %+v", tableName, databaseName, err)
+			}
+			for _, p := range tablePrincipals {
+				// the principals of a table include those inherited from the database, which are already listed
+				if matchesPrincipalEntityType(p, "table") && matches(p) {
+					principals = append(principals, flattenADXPrincipal(p, "table", tableName))
+				}
+			}
+		}
+	}
+
+	d.SetId(id)
+	d.Set("principals", principals)
+
+	return nil
+}
+
+func flattenADXPrincipal(p TablePrincipal, entityType string, entityName string) map[string]interface{} {
+	return map[string]interface{}{
+		"entity_type":    entityType,
+		"entity_name":    entityName,
+		"role":           p.Role,
+		"principal_type": p.PrincipalType,
+		"display_name":   p.PrincipalDisplayName,
+		"object_id":      p.PrincipalObjectId,
+		"fqn":            p.PrincipalFQN,
+		"notes":          p.Notes,
+	}
+}
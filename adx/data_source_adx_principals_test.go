@@ -0,0 +1,87 @@
+package adx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXPrincipalsDataSource_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	db := f.database("fake_db")
+	db.principals, _ = addPrincipals(db.principals, "admins", []string{"aaduser=admin@example.com"}, "owner")
+	db.principals, _ = addPrincipals(db.principals, "viewers", []string{"aadgroup=readers@example.com"}, "")
+	events := db.addTable("Events", "Timestamp:datetime")
+	events.principals, _ = addPrincipals(events.principals, "ingestors", []string{"aadapp=11111111-2222-3333-4444-555555555555"}, "")
+	metrics := db.addTable("Metrics", "Value:real")
+	metrics.principals, _ = addPrincipals(metrics.principals, "admins", []string{"aaduser=metrics@example.com"}, "")
+	r := newFakeResource(t, f, dataSourceADXPrincipals())
+
+	state, diags := r.read(map[string]interface{}{
+		"database_name": "fake_db",
+	})
+	assert.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, "4", state.Attributes["principals.#"])
+	assert.Equal(t, "database", state.Attributes["principals.0.entity_type"])
+	assert.Equal(t, "fake_db", state.Attributes["principals.0.entity_name"])
+	assert.Equal(t, "Database Admin", state.Attributes["principals.0.role"])
+	assert.Equal(t, "AAD User", state.Attributes["principals.0.principal_type"])
+	assert.Equal(t, "Fake Principal (upn: admin@example.com)", state.Attributes["principals.0.display_name"])
+	assert.Equal(t, fakeKustoObjectID("admin@example.com"), state.Attributes["principals.0.object_id"])
+	assert.Equal(t, fmt.Sprintf("aaduser=%s;%s", fakeKustoObjectID("admin@example.com"), fakeKustoTenantID), state.Attributes["principals.0.fqn"])
+	assert.Equal(t, "owner", state.Attributes["principals.0.notes"])
+	assert.Equal(t, "table", state.Attributes["principals.2.entity_type"])
+	assert.Equal(t, "Events", state.Attributes["principals.2.entity_name"])
+	assert.Equal(t, "Table Ingestor", state.Attributes["principals.2.role"])
+	assert.Equal(t, "Metrics", state.Attributes["principals.3.entity_name"])
+	assert.Empty(t, f.controlCommands())
+}
+
+func TestFakeADXPrincipalsDataSource_filters(t *testing.T) {
+	f := newFakeKusto(t)
+	db := f.database("fake_db")
+	db.principals, _ = addPrincipals(db.principals, "admins", []string{"aaduser=admin@example.com"}, "")
+	db.principals, _ = addPrincipals(db.principals, "viewers", []string{"aadgroup=readers@example.com"}, "")
+	db.principals, _ = addPrincipals(db.principals, "unrestrictedviewers", []string{"aaduser=auditor@example.com"}, "")
+	events := db.addTable("Events", "Timestamp:datetime")
+	events.principals, _ = addPrincipals(events.principals, "admins", []string{"aadgroup=events@example.com"}, "")
+	metrics := db.addTable("Metrics", "Value:real")
+	metrics.principals, _ = addPrincipals(metrics.principals, "admins", []string{"aaduser=metrics@example.com"}, "")
+	r := newFakeResource(t, f, dataSourceADXPrincipals())
+
+	// viewers must not match the UnrestrictedViewer role
+	state, diags := r.read(map[string]interface{}{
+		"database_name": "fake_db",
+		"role":          "viewers",
+	})
+	assert.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, "1", state.Attributes["principals.#"])
+	assert.Equal(t, "Database Viewer", state.Attributes["principals.0.role"])
+
+	state, diags = r.read(map[string]interface{}{
+		"database_name":  "fake_db",
+		"role":           "admins",
+		"principal_type": "aad user",
+	})
+	assert.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, "2", state.Attributes["principals.#"])
+	assert.Equal(t, "fake_db", state.Attributes["principals.0.entity_name"])
+	assert.Equal(t, "Metrics", state.Attributes["principals.1.entity_name"])
+
+	state, diags = r.read(map[string]interface{}{
+		"database_name": "fake_db",
+		"table_names":   []interface{}{"Events"},
+		"role":          "admins",
+	})
+	assert.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, "2", state.Attributes["principals.#"])
+	assert.Equal(t, "Events", state.Attributes["principals.1.entity_name"])
+
+	state, diags = r.read(map[string]interface{}{
+		"database_name":  "fake_db",
+		"include_tables": false,
+	})
+	assert.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, "3", state.Attributes["principals.#"])
+}
@@ -0,0 +1,306 @@
+package adx
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/Azure/azure-kusto-go/kusto"
+	"github.com/Azure/azure-kusto-go/kusto/data/table"
+	"github.com/Azure/azure-kusto-go/kusto/data/value"
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
+	"github.com/favoretti/terraform-provider-adx/adx/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var showCommandPattern = regexp.MustCompile(`(?i)^\.show\s`)
+
+func dataSourceADXQuery() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceADXQueryRead,
+
+		Schema: map[string]*schema.Schema{
+			"cluster": getClusterConfigInputSchema(),
+			"database_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+			},
+
+			"query": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+				Description:      "KQL query to run, or a .show command",
+			},
+
+			"parameter": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Query parameters, declared with declare query_parameters ahead of the query",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validate.StringIsNotEmpty,
+						},
+						"type": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "string",
+							ValidateDiagFunc: validate.StringInSlice(kql.ScalarTypes),
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"max_rows": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1000,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Maximum number of rows read from the result, further rows are dropped and truncated is set",
+			},
+
+			"columns": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"rows": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Rows of the result as maps of column name to the value formatted as a string",
+				Elem: &schema.Schema{
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{Type: schema.TypeString},
+				},
+			},
+
+			"result_json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Rows of the result as a JSON array of objects, keeping the type of the values",
+			},
+
+			"truncated": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceADXQueryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clusterConfig := getAndExpandClusterConfigWithDefaults(ctx, d, meta)
+	databaseName := d.Get("database_name").(string)
+	query := strings.TrimSpace(d.Get("query").(string))
+	maxRows := d.Get("max_rows").(int)
+
+	declaration, err := buildQueryParametersDeclaration(d.Get("parameter").([]interface{}))
+	if err != nil {
+		return diag.Errorf("%+v", err)
+	}
+
+	isCommand := strings.HasPrefix(query, ".")
+	if isCommand {
+		// a data source must not change the cluster, so only .show commands are run
+		if !showCommandPattern.MatchString(query) {
+			return diag.Errorf("only .show commands are supported, got: %s", query)
+		}
+		if declaration != "" {
+			return diag.Errorf("parameters are not supported with .show commands")
+		}
+	}
+
+	var resp *kusto.RowIterator
+	if isCommand {
+		resp, err = queryADXMgmt(ctx, meta, clusterConfig, databaseName, query)
+	} else {
+		resp, err = queryADX(ctx, meta, clusterConfig, databaseName, declaration+query)
+	}
+	if err != nil {
+		return diag.Errorf("%+v", err)
+	}
+	defer resp.Stop()
+
+	var columns table.Columns
+	rows := make([]interface{}, 0)
+	results := make([]map[string]interface{}, 0)
+	truncated := false
+	for {
+		row, err := resp.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return diag.Errorf("error reading query result (Database %q): %+v", databaseName, err)
+		}
+		if len(rows) == maxRows {
+			truncated = true
+			break
+		}
+
+		columns = row.ColumnTypes
+		formatted := make(map[string]interface{}, len(row.Values))
+		result := make(map[string]interface{}, len(row.Values))
+		for i, v := range row.Values {
+			name := row.ColumnTypes[i].Name
+			formatted[name] = formatKustoValue(v)
+			result[name] = kustoValueToJSON(v)
+		}
+		rows = append(rows, formatted)
+		results = append(results, result)
+	}
+
+	flattenedColumns := make([]interface{}, 0, len(columns))
+	for _, c := range columns {
+		flattenedColumns = append(flattenedColumns, map[string]interface{}{
+			"name": c.Name,
+			"type": string(c.Type),
+		})
+	}
+	// the client only exposes the columns of the result with its rows, an empty result is described by getschema
+	if len(rows) == 0 {
+		resultSchema, err := readQueryResultSchema(ctx, meta, clusterConfig, databaseName, isCommand, declaration+query)
+		if err != nil {
+			return diag.Errorf("%+v", err)
+		}
+		for _, c := range resultSchema {
+			flattenedColumns = append(flattenedColumns, map[string]interface{}{
+				"name": c.ColumnName,
+				"type": c.ColumnType,
+			})
+		}
+	}
+
+	resultJSON, err := json.Marshal(results)
+	if err != nil {
+		return diag.Errorf("error serializing query result (Database %q): %+v", databaseName, err)
+	}
+
+	d.SetId(buildADXResourceId(clusterConfig.URI, databaseName, "query", hex.EncodeToString(hashObjects(declaration+query, maxRows))))
+	d.Set("columns", flattenedColumns)
+	d.Set("rows", rows)
+	d.Set("result_json", string(resultJSON))
+	d.Set("truncated", truncated)
+
+	return nil
+}
+
+type QueryResultColumn struct {
+	ColumnName string
+	ColumnType string
+}
+
+// readQueryResultSchema reads the columns of the result of a query or .show command with getschema
+func readQueryResultSchema(ctx context.Context, meta interface{}, clusterConfig *ClusterConfig, databaseName string, isCommand bool, query string) ([]QueryResultColumn, error) {
+	// the operator goes on its own line so that a trailing comment does not swallow it
+	schemaQuery := strings.TrimRight(strings.TrimSpace(query), ";") + "\n| getschema"
+
+	var columns []QueryResultColumn
+	var err error
+	if isCommand {
+		columns, err = queryADXMgmtAndParse[QueryResultColumn](ctx, meta, clusterConfig, databaseName, schemaQuery)
+	} else {
+		columns, err = queryADXAndParse[QueryResultColumn](ctx, meta, clusterConfig, databaseName, schemaQuery)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading the columns of the query result (Database %q): %+v", databaseName, err)
+	}
+	return columns, nil
+}
+
+// buildQueryParametersDeclaration declares the parameters with their values as defaults, e.g.
+// declare query_parameters(tenant:string = 'contoso');
+func buildQueryParametersDeclaration(parameters []interface{}) (string, error) {
+	if len(parameters) == 0 {
+		return "", nil
+	}
+
+	declarations := make([]string, 0, len(parameters))
+	for _, v := range parameters {
+		parameter := v.(map[string]interface{})
+		name := parameter["name"].(string)
+		parameterType := parameter["type"].(string)
+		literal, err := kql.ScalarLiteral(parameterType, parameter["value"].(string))
+		if err != nil {
+			return "", fmt.Errorf("error in query parameter %q: %+v", name, err)
+		}
+		declarations = append(declarations, fmt.Sprintf("%s:%s = %s", kql.Identifier(name), parameterType, literal))
+	}
+	return "declare query_parameters(" + strings.Join(declarations, ", ") + ");\n", nil
+}
+
+// formatKustoValue formats a value as a string, null values as an empty string
+func formatKustoValue(v value.Kusto) string {
+	if v == nil {
+		return ""
+	}
+	// timespans are formatted as in ADX, e.g. 1.00:00:00, rather than as Go durations
+	if t, ok := v.(value.Timespan); ok && t.Valid {
+		return t.Marshal()
+	}
+	return v.String()
+}
+
+// kustoValueToJSON returns the value to serialize to JSON, numbers, bools and dynamic values keep their type
+func kustoValueToJSON(v value.Kusto) interface{} {
+	switch t := v.(type) {
+	case nil:
+		return nil
+	case value.Bool:
+		if t.Valid {
+			return t.Value
+		}
+	case value.Int:
+		if t.Valid {
+			return t.Value
+		}
+	case value.Long:
+		if t.Valid {
+			return t.Value
+		}
+	case value.Real:
+		if t.Valid {
+			return t.Value
+		}
+	case value.String:
+		if t.Valid {
+			return t.Value
+		}
+	case value.Dynamic:
+		if t.Valid && json.Valid(t.Value) {
+			return json.RawMessage(t.Value)
+		}
+	default:
+		if s := formatKustoValue(v); s != "" {
+			return s
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package adx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXQueryDataSource_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	result := newFakeKustoResult("Tenant:string", "Count:long", "Ratio:real", "Enabled:bool", "FirstSeen:datetime", "Retention:timespan", "Tags:dynamic")
+	result.addRow("contoso", 42, 0.5, true, "2024-01-01T00:00:00Z", "30.00:00:00", map[string]interface{}{"tier": "gold"})
+	result.addRow("fabrikam", nil, nil, false, nil, nil, nil)
+	f.answerQuery("Tenants | project Tenant, Count, Ratio, Enabled, FirstSeen, Retention, Tags", result)
+	r := newFakeResource(t, f, dataSourceADXQuery())
+
+	state, diags := r.read(map[string]interface{}{
+		"database_name": "fake_db",
+		"query":         "Tenants | project Tenant, Count, Ratio, Enabled, FirstSeen, Retention, Tags",
+	})
+	assert.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, "7", state.Attributes["columns.#"])
+	assert.Equal(t, "Count", state.Attributes["columns.1.name"])
+	assert.Equal(t, "long", state.Attributes["columns.1.type"])
+	assert.Equal(t, "2", state.Attributes["rows.#"])
+	assert.Equal(t, "contoso", state.Attributes["rows.0.Tenant"])
+	assert.Equal(t, "42", state.Attributes["rows.0.Count"])
+	assert.Equal(t, "30.00:00:00", state.Attributes["rows.0.Retention"])
+	assert.Equal(t, "", state.Attributes["rows.1.Count"])
+	assert.Equal(t, "false", state.Attributes["truncated"])
+	assert.JSONEq(t, `[
+		{"Tenant":"contoso","Count":42,"Ratio":0.5,"Enabled":true,"FirstSeen":"2024-01-01T00:00:00Z","Retention":"30.00:00:00","Tags":{"tier":"gold"}},
+		{"Tenant":"fabrikam","Count":null,"Ratio":null,"Enabled":false,"FirstSeen":null,"Retention":null,"Tags":null}
+	]`, state.Attributes["result_json"])
+}
+
+func TestFakeADXQueryDataSource_noRows(t *testing.T) {
+	f := newFakeKusto(t)
+	f.answerQuery("Tenants | where false", newFakeKustoResult("Tenant:string", "Count:long"))
+	columns := newFakeKustoResult("ColumnName:string", "ColumnOrdinal:int", "DataType:string", "ColumnType:string")
+	columns.addRow("Tenant", 0, "System.String", "string")
+	columns.addRow("Count", 1, "System.Int64", "long")
+	f.answerQuery("Tenants | where false\n| getschema", columns)
+	r := newFakeResource(t, f, dataSourceADXQuery())
+
+	state, diags := r.read(map[string]interface{}{
+		"database_name": "fake_db",
+		"query":         "Tenants | where false",
+	})
+	assert.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, "0", state.Attributes["rows.#"])
+	assert.Equal(t, "2", state.Attributes["columns.#"])
+	assert.Equal(t, "Count", state.Attributes["columns.1.name"])
+	assert.Equal(t, "long", state.Attributes["columns.1.type"])
+	assert.Equal(t, "[]", state.Attributes["result_json"])
+}
+
+func TestFakeADXQueryDataSource_maxRows(t *testing.T) {
+	f := newFakeKusto(t)
+	result := newFakeKustoResult("Tenant:string")
+	result.addRow("contoso")
+	result.addRow("fabrikam")
+	result.addRow("northwind")
+	f.answerQuery("Tenants", result)
+	r := newFakeResource(t, f, dataSourceADXQuery())
+
+	state, diags := r.read(map[string]interface{}{
+		"database_name": "fake_db",
+		"query":         "Tenants",
+		"max_rows":      2,
+	})
+	assert.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, "2", state.Attributes["rows.#"])
+	assert.Equal(t, "fabrikam", state.Attributes["rows.1.Tenant"])
+	assert.Equal(t, "true", state.Attributes["truncated"])
+}
+
+func TestFakeADXQueryDataSource_parameters(t *testing.T) {
+	f := newFakeKusto(t)
+	result := newFakeKustoResult("Tenant:string")
+	result.addRow("contoso")
+	f.answerQuery("declare query_parameters(tenant:string = 'contoso', ['min count']:long = long(10));\nTenants | where Tenant == tenant and Count >= ['min count']", result)
+	r := newFakeResource(t, f, dataSourceADXQuery())
+
+	state, diags := r.read(map[string]interface{}{
+		"database_name": "fake_db",
+		"query":         "Tenants | where Tenant == tenant and Count >= ['min count']",
+		"parameter": []interface{}{
+			map[string]interface{}{"name": "tenant", "value": "contoso"},
+			map[string]interface{}{"name": "min count", "type": "long", "value": "10"},
+		},
+	})
+	assert.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, "contoso", state.Attributes["rows.0.Tenant"])
+
+	_, diags = r.read(map[string]interface{}{
+		"database_name": "fake_db",
+		"query":         "Tenants | where Count >= min_count",
+		"parameter": []interface{}{
+			map[string]interface{}{"name": "min_count", "type": "long", "value": "10) | take 1 //"},
+		},
+	})
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "invalid long value")
+}
+
+func TestFakeADXQueryDataSource_showCommand(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db").addFunction("Tenants", "()", "{ print Tenant = 'contoso' }")
+	r := newFakeResource(t, f, dataSourceADXQuery())
+
+	state, diags := r.read(map[string]interface{}{
+		"database_name": "fake_db",
+		"query":         ".show functions",
+	})
+	assert.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, "1", state.Attributes["rows.#"])
+	assert.Equal(t, "Tenants", state.Attributes["rows.0.Name"])
+
+	_, diags = r.read(map[string]interface{}{
+		"database_name": "fake_db",
+		"query":         ".drop function Tenants",
+	})
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "only .show commands are supported")
+	assert.Empty(t, f.controlCommands())
+}
@@ -0,0 +1,177 @@
+package adx
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-kusto-go/kusto/data/value"
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
+	"github.com/favoretti/terraform-provider-adx/adx/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type TableDetails struct {
+	TableName              string
+	DatabaseName           string
+	Folder                 string
+	DocString              string
+	TotalExtents           int64
+	TotalExtentSize        float64
+	TotalOriginalSize      float64
+	TotalRowCount          int64
+	HotExtents             int64
+	HotExtentSize          float64
+	HotRowCount            int64
+	MinExtentsCreationTime value.DateTime
+	MaxExtentsCreationTime value.DateTime
+}
+
+func dataSourceADXTable() *schema.Resource {
+	tableSchema := tableDataSourceSchema()
+	tableSchema["cluster"] = getClusterConfigInputSchema()
+	tableSchema["database_name"] = &schema.Schema{
+		Type:             schema.TypeString,
+		Required:         true,
+		ValidateDiagFunc: validate.StringIsNotEmpty,
+	}
+	tableSchema["name"] = &schema.Schema{
+		Type:             schema.TypeString,
+		Required:         true,
+		ValidateDiagFunc: validate.StringIsNotEmpty,
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceADXTableRead,
+		Schema:      tableSchema,
+	}
+}
+
+// tableDataSourceSchema holds the computed attributes of a table, shared by the adx_table and adx_tables data sources
+func tableDataSourceSchema() map[string]*schema.Schema {
+	computedString := func() *schema.Schema { return &schema.Schema{Type: schema.TypeString, Computed: true} }
+	computedInt := func() *schema.Schema { return &schema.Schema{Type: schema.TypeInt, Computed: true} }
+	computedFloat := func() *schema.Schema { return &schema.Schema{Type: schema.TypeFloat, Computed: true} }
+
+	return map[string]*schema.Schema{
+		"name":         computedString(),
+		"table_schema": computedString(),
+		"column": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": computedString(),
+					"type": computedString(),
+				},
+			},
+		},
+		"folder":                    computedString(),
+		"docstring":                 computedString(),
+		"total_extents":             computedInt(),
+		"total_extent_size":         computedFloat(),
+		"total_original_size":       computedFloat(),
+		"total_row_count":           computedInt(),
+		"hot_extents":               computedInt(),
+		"hot_extent_size":           computedFloat(),
+		"hot_row_count":             computedInt(),
+		"min_extents_creation_time": computedString(),
+		"max_extents_creation_time": computedString(),
+	}
+}
+
+func dataSourceADXTableRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clusterConfig := getAndExpandClusterConfigWithDefaults(ctx, d, meta)
+	databaseName := d.Get("database_name").(string)
+	tableName := d.Get("name").(string)
+
+	details, err := readADXTablesDetails(ctx, meta, clusterConfig, databaseName, tableName)
+	if err != nil {
+		return diag.Errorf("%+v", err)
+	}
+	if len(details) == 0 {
+		return diag.Errorf("Table %q (Database %q) was not found", tableName, databaseName)
+	}
+
+	tableSchema, err := readADXTableSchema(ctx, meta, clusterConfig, databaseName, tableName)
+	if err != nil {
+		return diag.Errorf("%+v", err)
+	}
+
+	d.SetId(buildADXResourceId(clusterConfig.URI, databaseName, "table", details[0].TableName))
+	for k, v := range flattenTableDetails(details[0], tableSchema) {
+		d.Set(k, v)
+	}
+
+	return nil
+}
+
+// readADXTablesDetails reads the details of the given tables, or of all tables of the database when none are given
+func readADXTablesDetails(ctx context.Context, meta interface{}, clusterConfig *ClusterConfig, databaseName string, tableNames ...string) ([]TableDetails, error) {
+	showStatement := kql.New(".show", "tables")
+	if len(tableNames) > 0 {
+		showStatement.IdentifierList(tableNames...)
+	}
+	showStatement.Keyword("details")
+
+	details, err := queryADXMgmtAndParse[TableDetails](ctx, meta, clusterConfig, databaseName, showStatement.String())
+	if err != nil {
+		return nil, fmt.Errorf("error reading table details (Database %q): %+v", databaseName, err)
+	}
+	return details, nil
+}
+
+func readADXTableSchema(ctx context.Context, meta interface{}, clusterConfig *ClusterConfig, databaseName string, tableName string) (*TableSchema, error) {
+	showStatement := kql.New(".show", "table").Identifier(tableName).Keyword("cslschema").String()
+
+	schemas, err := queryADXMgmtAndParse[TableSchema](ctx, meta, clusterConfig, databaseName, showStatement)
+	if err != nil {
+		return nil, fmt.Errorf("error reading schema for Table %q (Database %q): %+v", tableName, databaseName, err)
+	}
+	if len(schemas) == 0 {
+		return nil, fmt.Errorf("error reading schema for Table %q (Database %q): no schema returned", tableName, databaseName)
+	}
+	return &schemas[0], nil
+}
+
+// readADXTablesSchemas reads the schema of every table of the database with a single command, keyed by table name
+func readADXTablesSchemas(ctx context.Context, meta interface{}, clusterConfig *ClusterConfig, databaseName string) (map[string]TableSchema, error) {
+	showStatement := kql.New(".show", "database").Identifier(databaseName).Keyword("cslschema").String()
+
+	schemas, err := queryADXMgmtAndParse[TableSchema](ctx, meta, clusterConfig, databaseName, showStatement)
+	if err != nil {
+		return nil, fmt.Errorf("error reading table schemas (Database %q): %+v", databaseName, err)
+	}
+	result := make(map[string]TableSchema, len(schemas))
+	for _, s := range schemas {
+		result[s.TableName] = s
+	}
+	return result, nil
+}
+
+func flattenTableDetails(details TableDetails, tableSchema *TableSchema) map[string]interface{} {
+	formatTime := func(t value.DateTime) string {
+		if !t.Valid {
+			return ""
+		}
+		return t.Value.Format(time.RFC3339Nano)
+	}
+
+	return map[string]interface{}{
+		"name":                      details.TableName,
+		"table_schema":              tableSchema.Schema,
+		"column":                    flattenTableColumn(tableSchema.Schema),
+		"folder":                    details.Folder,
+		"docstring":                 details.DocString,
+		"total_extents":             int(details.TotalExtents),
+		"total_extent_size":         details.TotalExtentSize,
+		"total_original_size":       details.TotalOriginalSize,
+		"total_row_count":           int(details.TotalRowCount),
+		"hot_extents":               int(details.HotExtents),
+		"hot_extent_size":           details.HotExtentSize,
+		"hot_row_count":             int(details.HotRowCount),
+		"min_extents_creation_time": formatTime(details.MinExtentsCreationTime),
+		"max_extents_creation_time": formatTime(details.MaxExtentsCreationTime),
+	}
+}
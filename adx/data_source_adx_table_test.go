@@ -0,0 +1,48 @@
+package adx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXTableDataSource_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	table := f.database("fake_db").addTable("Events", "Timestamp:datetime,['Event Name']:string")
+	table.folder = "telemetry"
+	table.docString = "Raw events"
+	table.rowCount = 1000
+	table.extents = 2
+	r := newFakeResource(t, f, dataSourceADXTable())
+
+	state, diags := r.read(map[string]interface{}{
+		"database_name": "fake_db",
+		"name":          "Events",
+	})
+	assert.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|table|Events", f.endpoint()), state.ID)
+	assert.Equal(t, "Timestamp:datetime,['Event Name']:string", state.Attributes["table_schema"])
+	assert.Equal(t, "2", state.Attributes["column.#"])
+	assert.Equal(t, "Event Name", state.Attributes["column.1.name"])
+	assert.Equal(t, "string", state.Attributes["column.1.type"])
+	assert.Equal(t, "telemetry", state.Attributes["folder"])
+	assert.Equal(t, "Raw events", state.Attributes["docstring"])
+	assert.Equal(t, "1000", state.Attributes["total_row_count"])
+	assert.Equal(t, "2", state.Attributes["total_extents"])
+	assert.Equal(t, "64000", state.Attributes["total_extent_size"])
+	assert.Equal(t, "2024-01-01T00:00:00Z", state.Attributes["min_extents_creation_time"])
+	assert.Empty(t, f.controlCommands())
+}
+
+func TestFakeADXTableDataSource_notFound(t *testing.T) {
+	f := newFakeKusto(t)
+	r := newFakeResource(t, f, dataSourceADXTable())
+
+	_, diags := r.read(map[string]interface{}{
+		"database_name": "fake_db",
+		"name":          "Missing",
+	})
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, `Table "Missing" (Database "fake_db") was not found`)
+}
@@ -0,0 +1,101 @@
+package adx
+
+import (
+	"context"
+	"encoding/hex"
+	"regexp"
+
+	"github.com/favoretti/terraform-provider-adx/adx/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceADXTables() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceADXTablesRead,
+
+		Schema: map[string]*schema.Schema{
+			"cluster": getClusterConfigInputSchema(),
+			"database_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+			},
+
+			"folder": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the tables in this folder",
+			},
+
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "Only return the tables whose name matches this regular expression",
+			},
+
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"tables": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: tableDataSourceSchema(),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceADXTablesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clusterConfig := getAndExpandClusterConfigWithDefaults(ctx, d, meta)
+	databaseName := d.Get("database_name").(string)
+	folder, filterFolder := d.GetOk("folder")
+	nameRegex := d.Get("name_regex").(string)
+
+	var namePattern *regexp.Regexp
+	if nameRegex != "" {
+		namePattern = regexp.MustCompile(nameRegex)
+	}
+
+	details, err := readADXTablesDetails(ctx, meta, clusterConfig, databaseName)
+	if err != nil {
+		return diag.Errorf("%+v", err)
+	}
+
+	schemas, err := readADXTablesSchemas(ctx, meta, clusterConfig, databaseName)
+	if err != nil {
+		return diag.Errorf("%+v", err)
+	}
+
+	names := make([]string, 0)
+	tables := make([]interface{}, 0)
+	for _, table := range details {
+		if filterFolder && table.Folder != folder.(string) {
+			continue
+		}
+		if namePattern != nil && !namePattern.MatchString(table.TableName) {
+			continue
+		}
+
+		// a table created after the schemas were read has no schema yet and is left out
+		tableSchema, ok := schemas[table.TableName]
+		if !ok {
+			continue
+		}
+		names = append(names, table.TableName)
+		tables = append(tables, flattenTableDetails(table, &tableSchema))
+	}
+
+	d.SetId(buildADXResourceId(clusterConfig.URI, databaseName, "tables", hex.EncodeToString(hashObjects(folder, nameRegex))))
+	d.Set("names", names)
+	d.Set("tables", tables)
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package adx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXTablesDataSource_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	db := f.database("fake_db")
+	db.addTable("Events", "Timestamp:datetime,Name:string").folder = "telemetry"
+	db.addTable("EventsArchive", "Timestamp:datetime,Name:string").folder = "archive"
+	db.addTable("Metrics", "Timestamp:datetime,Value:real").folder = "telemetry"
+	r := newFakeResource(t, f, dataSourceADXTables())
+
+	state, diags := r.read(map[string]interface{}{
+		"database_name": "fake_db",
+	})
+	assert.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, "3", state.Attributes["names.#"])
+	assert.Equal(t, "Value", state.Attributes["tables.2.column.1.name"])
+	assert.Equal(t, 1, countCommands(f, ".show database fake_db cslschema"), "the schemas should be read with a single command")
+	assert.Equal(t, 0, countCommands(f, ".show table "))
+
+	state, diags = r.read(map[string]interface{}{
+		"database_name": "fake_db",
+		"folder":        "telemetry",
+	})
+	assert.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, "2", state.Attributes["names.#"])
+	assert.Equal(t, "Events", state.Attributes["names.0"])
+	assert.Equal(t, "Metrics", state.Attributes["names.1"])
+
+	state, diags = r.read(map[string]interface{}{
+		"database_name": "fake_db",
+		"name_regex":    "^Events",
+	})
+	assert.False(t, diags.HasError(), "%+v", diags)
+	assert.Equal(t, "2", state.Attributes["names.#"])
+	assert.Equal(t, "EventsArchive", state.Attributes["tables.1.name"])
+	assert.Equal(t, "archive", state.Attributes["tables.1.folder"])
+}
@@ -0,0 +1,464 @@
+package adx
+
+import (
+	"crypto/md5"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fakeKustoCluster holds the cluster scoped entities of the fake cluster
+type fakeKustoCluster struct {
+	policies       map[string]string
+	workloadGroups map[string]string
+	principals     []fakeKustoPrincipal
+}
+
+func newFakeKustoCluster() *fakeKustoCluster {
+	return &fakeKustoCluster{
+		policies:       make(map[string]string),
+		workloadGroups: make(map[string]string),
+	}
+}
+
+// fakeKustoDatabase holds the database scoped entities of the fake cluster
+type fakeKustoDatabase struct {
+	name string
+	// fabricID is the UUID a Fabric KQL database reports instead of its name, empty for ADX databases
+	fabricID          string
+	tables            map[string]*fakeKustoTable
+	functions         map[string]*fakeKustoFunction
+	materializedViews map[string]*fakeKustoMaterializedView
+	externalTables    map[string]*fakeKustoExternalTable
+	continuousExports map[string]*fakeKustoContinuousExport
+	principals        []fakeKustoPrincipal
+
+	// policies are keyed by "<entity type>|<entity name>|<policy name>" and hold the policy JSON
+	policies map[string]string
+}
+
+func newFakeKustoDatabase(name string) *fakeKustoDatabase {
+	return &fakeKustoDatabase{
+		name:              name,
+		tables:            make(map[string]*fakeKustoTable),
+		functions:         make(map[string]*fakeKustoFunction),
+		materializedViews: make(map[string]*fakeKustoMaterializedView),
+		externalTables:    make(map[string]*fakeKustoExternalTable),
+		continuousExports: make(map[string]*fakeKustoContinuousExport),
+		policies:          make(map[string]string),
+	}
+}
+
+// reportedName is the name the cluster reports for the database in responses
+func (db *fakeKustoDatabase) reportedName() string {
+	if db.fabricID != "" {
+		return db.fabricID
+	}
+	return db.name
+}
+
+type fakeKustoTable struct {
+	name       string
+	schema     string
+	folder     string
+	docString  string
+	mappings   map[string]*fakeKustoMapping
+	principals []fakeKustoPrincipal
+	// rowCount and extents are the statistics .show tables details reports
+	rowCount int64
+	extents  int64
+}
+
+// fakeKustoExtentsCreationTime is the creation time reported for the extents of all tables
+const fakeKustoExtentsCreationTime = "2024-01-01T00:00:00Z"
+
+type fakeKustoMapping struct {
+	name          string
+	kind          string
+	mapping       string
+	lastUpdatedOn time.Time
+}
+
+type fakeKustoFunction struct {
+	name       string
+	parameters string
+	body       string
+	folder     string
+	docString  string
+	principals []fakeKustoPrincipal
+}
+
+type fakeKustoMaterializedView struct {
+	name              string
+	sourceTable       string
+	query             string
+	folder            string
+	docString         string
+	autoUpdateSchema  bool
+	effectiveDateTime time.Time
+	principals        []fakeKustoPrincipal
+}
+
+type fakeKustoExternalTable struct {
+	name              string
+	schema            string
+	kind              string
+	dataFormat        string
+	connectionStrings string
+	partitions        string
+	pathFormat        string
+	folder            string
+	properties        string
+	principals        []fakeKustoPrincipal
+}
+
+type fakeKustoContinuousExport struct {
+	name              string
+	externalTableName string
+	query             string
+}
+
+type fakeKustoPrincipal struct {
+	role  string
+	fqn   string
+	upn   string
+	notes string
+}
+
+// addTable creates a table directly in the catalog, for tests that need an existing table
+func (db *fakeKustoDatabase) addTable(name string, schema string) *fakeKustoTable {
+	t := &fakeKustoTable{name: name, schema: schema, mappings: make(map[string]*fakeKustoMapping)}
+	db.tables[name] = t
+	return t
+}
+
+// addMaterializedView creates a materialized view directly in the catalog
+func (db *fakeKustoDatabase) addMaterializedView(name string, sourceTable string, query string) *fakeKustoMaterializedView {
+	v := &fakeKustoMaterializedView{name: name, sourceTable: sourceTable, query: query, effectiveDateTime: time.Now().UTC()}
+	db.materializedViews[name] = v
+	return v
+}
+
+// addFunction creates a function directly in the catalog
+func (db *fakeKustoDatabase) addFunction(name string, parameters string, body string) *fakeKustoFunction {
+	fn := &fakeKustoFunction{name: name, parameters: parameters, body: body}
+	db.functions[name] = fn
+	return fn
+}
+
+// addExternalTable creates an external table directly in the catalog
+func (db *fakeKustoDatabase) addExternalTable(name string, schema string) *fakeKustoExternalTable {
+	t := &fakeKustoExternalTable{name: name, schema: schema, kind: "storage", dataFormat: "csv"}
+	db.externalTables[name] = t
+	return t
+}
+
+func (db *fakeKustoDatabase) table(name string) (*fakeKustoTable, error) {
+	t, ok := db.tables[fakeKustoUnquote(name)]
+	if !ok {
+		return nil, fakeKustoEntityNotFound("Table '%s' was not found in database '%s'", fakeKustoUnquote(name), db.name)
+	}
+	return t, nil
+}
+
+func (db *fakeKustoDatabase) policyKey(entityType string, entityName string, policyName string) string {
+	return fmt.Sprintf("%s|%s|%s", entityType, fakeKustoUnquote(entityName), policyName)
+}
+
+// columns returns the column names of an inline table schema such as "f1:string,f2:int"
+func (t *fakeKustoTable) columns() []string {
+	var names []string
+	for _, column := range fakeKustoSplitTopLevel(t.schema, ',') {
+		names = append(names, fakeKustoUnquote(strings.SplitN(column, ":", 2)[0]))
+	}
+	return names
+}
+
+// fakeKustoNormalizeSchema removes the whitespace around the columns of an inline schema, keeping the
+// whitespace inside quoted column names
+func fakeKustoNormalizeSchema(schema string) string {
+	var columns []string
+	for _, column := range fakeKustoSplitTopLevel(schema, ',') {
+		i := strings.LastIndex(column, ":")
+		if i < 0 {
+			columns = append(columns, strings.TrimSpace(column))
+			continue
+		}
+		columns = append(columns, strings.TrimSpace(column[:i])+":"+strings.TrimSpace(column[i+1:]))
+	}
+	return strings.Join(columns, ",")
+}
+
+// mergeSchema adds the columns of the given schema that the table does not have yet, as .alter-merge does
+func (t *fakeKustoTable) mergeSchema(schema string) {
+	existing := map[string]bool{}
+	for _, c := range t.columns() {
+		existing[c] = true
+	}
+	columns := fakeKustoSplitTopLevel(t.schema, ',')
+	for _, column := range fakeKustoSplitTopLevel(schema, ',') {
+		if !existing[fakeKustoUnquote(strings.SplitN(column, ":", 2)[0])] {
+			columns = append(columns, column)
+		}
+	}
+	t.schema = strings.Join(columns, ",")
+}
+
+// entityPrincipals returns the principals of a function, materialized view or external table together with
+// the entity kind the cluster reports in their role
+func (db *fakeKustoDatabase) entityPrincipals(kind string, name string) (*[]fakeKustoPrincipal, string, error) {
+	name = fakeKustoUnquote(name)
+	switch strings.Join(strings.Fields(strings.ToLower(kind)), " ") {
+	case "function":
+		if fn, ok := db.functions[name]; ok {
+			return &fn.principals, "Function", nil
+		}
+	case "materialized-view":
+		if mv, ok := db.materializedViews[name]; ok {
+			return &mv.principals, "MaterializedView", nil
+		}
+	case "external table":
+		if t, ok := db.externalTables[name]; ok {
+			return &t.principals, "ExternalTable", nil
+		}
+	}
+	return nil, "", fakeKustoEntityNotFound("Entity '%s' of kind '%s' was not found", name, kind)
+}
+
+var fakeKustoPrincipalRoles = map[string]string{
+	"admins":              "Admin",
+	"ingestors":           "Ingestor",
+	"viewers":             "Viewer",
+	"unrestrictedviewers": "UnrestrictedViewer",
+	"users":               "User",
+	"monitors":            "Monitor",
+
+	// cluster roles are reported without an entity kind
+	"alldatabasesadmin":   "AllDatabasesAdmin",
+	"alldatabasesviewer":  "AllDatabasesViewer",
+	"alldatabasesmonitor": "AllDatabasesMonitor",
+}
+
+var fakeKustoPrincipalTypes = map[string]string{
+	"aaduser":  "AAD User",
+	"aadgroup": "AAD Group",
+	"aadapp":   "AAD Application",
+	"msauser":  "MSA User",
+}
+
+const fakeKustoTenantID = "72f988bf-86f1-41af-91ab-2d7cd011db47"
+
+// resolvePrincipalFQN mimics the cluster resolving user and group UPNs to object ids
+func resolvePrincipalFQN(fqn string) string {
+	parts := strings.SplitN(fqn, "=", 2)
+	if len(parts) != 2 {
+		return fqn
+	}
+	kind := strings.ToLower(parts[0])
+	if (kind == "aaduser" || kind == "aadgroup") && strings.Contains(parts[1], "@") && !strings.Contains(parts[1], ";") {
+		return fmt.Sprintf("%s=%s;%s", kind, fakeKustoObjectID(parts[1]), fakeKustoTenantID)
+	}
+	return fmt.Sprintf("%s=%s", kind, parts[1])
+}
+
+// fakeKustoObjectID derives a stable object id for a principal identifier
+func fakeKustoObjectID(identifier string) string {
+	h := md5.Sum([]byte(strings.ToLower(identifier)))
+	return fmt.Sprintf("%x-%x-%x-%x-%x", h[0:4], h[4:6], h[6:8], h[8:10], h[10:16])
+}
+
+func (p fakeKustoPrincipal) principalType() string {
+	return fakeKustoPrincipalTypes[strings.ToLower(strings.SplitN(p.fqn, "=", 2)[0])]
+}
+
+func (p fakeKustoPrincipal) displayName() string {
+	identifier := strings.SplitN(p.fqn, "=", 2)[1]
+	switch p.principalType() {
+	case "AAD Application":
+		return fmt.Sprintf("Fake Application (app id: %s)", strings.SplitN(identifier, ";", 2)[0])
+	case "AAD User", "AAD Group":
+		if p.upn != "" {
+			return fmt.Sprintf("Fake Principal (upn: %s)", p.upn)
+		}
+	}
+	return fmt.Sprintf("Fake Principal (%s)", identifier)
+}
+
+func (p fakeKustoPrincipal) objectID() string {
+	identifier := strings.SplitN(p.fqn, "=", 2)[1]
+	return strings.SplitN(identifier, ";", 2)[0]
+}
+
+// addPrincipals adds principals to a role, returning an error for an unknown role or principal kind
+func addPrincipals(principals []fakeKustoPrincipal, role string, fqns []string, notes string) ([]fakeKustoPrincipal, error) {
+	if _, ok := fakeKustoPrincipalRoles[role]; !ok {
+		return principals, fmt.Errorf("unknown role '%s'", role)
+	}
+	for _, fqn := range fqns {
+		resolved := resolvePrincipalFQN(fqn)
+		if _, ok := fakeKustoPrincipalTypes[strings.SplitN(resolved, "=", 2)[0]]; !ok {
+			return principals, fmt.Errorf("invalid principal '%s'", fqn)
+		}
+		// the cluster reports the UPN a principal was added with in its display name
+		upn := ""
+		if resolved != fqn && strings.Contains(fqn, "@") {
+			upn = strings.SplitN(fqn, "=", 2)[1]
+		}
+		principals = dropPrincipals(principals, role, []string{fqn})
+		principals = append(principals, fakeKustoPrincipal{role: role, fqn: resolved, upn: upn, notes: notes})
+	}
+	return principals, nil
+}
+
+// dropPrincipals removes principals from a role, matching either the given or the resolved FQN
+func dropPrincipals(principals []fakeKustoPrincipal, role string, fqns []string) []fakeKustoPrincipal {
+	drop := map[string]bool{}
+	for _, fqn := range fqns {
+		drop[strings.ToLower(fqn)] = true
+		drop[strings.ToLower(resolvePrincipalFQN(fqn))] = true
+	}
+	var result []fakeKustoPrincipal
+	for _, p := range principals {
+		if p.role == role && drop[strings.ToLower(p.fqn)] {
+			continue
+		}
+		result = append(result, p)
+	}
+	return result
+}
+
+// principalsResult formats principals as .show <entity> principals does, e.g. role "Table Admin"
+func principalsResult(principals []fakeKustoPrincipal, entityKind string) *fakeKustoResult {
+	result := newFakeKustoResult("Role:string", "PrincipalType:string", "PrincipalDisplayName:string", "PrincipalObjectId:string", "PrincipalFQN:string", "Notes:string")
+	for _, p := range principals {
+		result.addRow(strings.TrimSpace(entityKind+" "+fakeKustoPrincipalRoles[p.role]), p.principalType(), p.displayName(), p.objectID(), p.fqn, p.notes)
+	}
+	return result
+}
+
+// fakeKustoParseList parses a parenthesised list of string literals or names such as ('a', 'b')
+func fakeKustoParseList(list string) []string {
+	list = strings.TrimSpace(list)
+	list = strings.TrimSuffix(strings.TrimPrefix(list, "("), ")")
+	var items []string
+	for _, item := range fakeKustoSplitTopLevel(list, ',') {
+		if value, ok := fakeKustoDecodeString(item); ok {
+			items = append(items, value)
+		} else if item = strings.TrimSpace(item); item != "" {
+			items = append(items, fakeKustoUnquote(item))
+		}
+	}
+	return items
+}
+
+// fakeKustoSplitTopLevel splits on sep outside of string literals, brackets and parentheses
+func fakeKustoSplitTopLevel(s string, sep rune) []string {
+	var parts []string
+	var quote rune
+	escaped := false
+	depth := 0
+	start := 0
+	for i, c := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case quote != 0 && c == '\\':
+			escaped = true
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+		case c == sep && depth == 0:
+			parts = append(parts, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	if rest := strings.TrimSpace(s[start:]); rest != "" {
+		parts = append(parts, rest)
+	}
+	return parts
+}
+
+// fakeKustoParseProperties parses the contents of a with(...) clause into lower cased keys and unquoted values
+func fakeKustoParseProperties(with string) map[string]string {
+	properties := map[string]string{}
+	for _, property := range fakeKustoSplitTopLevel(with, ',') {
+		kv := strings.SplitN(property, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		value := strings.TrimSpace(kv[1])
+		if decoded, ok := fakeKustoDecodeString(value); ok {
+			value = decoded
+		}
+		properties[strings.ToLower(strings.TrimSpace(kv[0]))] = value
+	}
+	return properties
+}
+
+var fakeKustoTimespanLiteralPattern = regexp.MustCompile(`^(\d+(?:\.\d+)?)(d|h|m|s|ms|microsecond|tick)$`)
+var fakeKustoTimespanPattern = regexp.MustCompile(`^(?:(\d+)\.)?(\d{1,2}):(\d{2}):(\d{2})(?:\.(\d{1,7}))?$`)
+
+// parseFakeKustoTimespan parses timespan literals (30d, 500m) and the [d.]hh:mm:ss[.fffffff] format
+func parseFakeKustoTimespan(input string) (time.Duration, error) {
+	input = strings.TrimSpace(input)
+	if m := fakeKustoTimespanLiteralPattern.FindStringSubmatch(input); m != nil {
+		amount, _ := strconv.ParseFloat(m[1], 64)
+		unit := map[string]time.Duration{
+			"d":           24 * time.Hour,
+			"h":           time.Hour,
+			"m":           time.Minute,
+			"s":           time.Second,
+			"ms":          time.Millisecond,
+			"microsecond": time.Microsecond,
+			"tick":        100 * time.Nanosecond,
+		}[m[2]]
+		return time.Duration(amount * float64(unit)), nil
+	}
+	if m := fakeKustoTimespanPattern.FindStringSubmatch(input); m != nil {
+		var d time.Duration
+		days, _ := strconv.Atoi(m[1])
+		hours, _ := strconv.Atoi(m[2])
+		minutes, _ := strconv.Atoi(m[3])
+		seconds, _ := strconv.Atoi(m[4])
+		d = time.Duration(days)*24*time.Hour + time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+		if m[5] != "" {
+			ticks, _ := strconv.Atoi((m[5] + "000000")[:7])
+			d += time.Duration(ticks) * 100 * time.Nanosecond
+		}
+		return d, nil
+	}
+	return 0, fmt.Errorf("invalid timespan '%s'", input)
+}
+
+// formatFakeKustoTimespan formats a duration the way the cluster reports timespans
+func formatFakeKustoTimespan(d time.Duration) string {
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	result := fmt.Sprintf("%02d:%02d:%02d", d/time.Hour, (d%time.Hour)/time.Minute, (d%time.Minute)/time.Second)
+	if days > 0 {
+		result = fmt.Sprintf("%d.%s", days, result)
+	}
+	if ticks := (d % time.Second) / (100 * time.Nanosecond); ticks > 0 {
+		result = fmt.Sprintf("%s.%07d", result, ticks)
+	}
+	return result
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,1165 @@
+package adx
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fakeKustoHandler answers the commands matching pattern, m holds the pattern's submatches
+type fakeKustoHandler struct {
+	pattern *regexp.Regexp
+	handle  func(f *fakeKusto, db *fakeKustoDatabase, m []string) (*fakeKustoResult, error)
+}
+
+// fakeKustoString matches a string literal, backslash escapes included
+const fakeKustoString = `(?:@?'(?:[^'\\]|\\.)*'|@?"(?:[^"\\]|\\.)*")`
+
+// fakeKustoName matches a plain or bracket-quoted entity name
+const fakeKustoName = `(\[` + fakeKustoString + `\]|[\w-]+)`
+
+// fakeKustoCommandPattern compiles a command pattern, NAME stands for an entity name and STRING for a
+// string literal
+func fakeKustoCommandPattern(pattern string) *regexp.Regexp {
+	pattern = strings.ReplaceAll(pattern, "NAME", fakeKustoName)
+	pattern = strings.ReplaceAll(pattern, "STRING", "("+fakeKustoString+")")
+	return regexp.MustCompile(`(?is)^` + pattern + `$`)
+}
+
+var fakeKustoMgmtHandlers = []fakeKustoHandler{
+	// databases
+	{fakeKustoCommandPattern(`\.show\s+database(?:\s+NAME)?`), (*fakeKusto).showDatabase},
+	{fakeKustoCommandPattern(`\.show\s+database\s+NAME\s+schema\s+as\s+json`), (*fakeKusto).showDatabaseSchemaJSON},
+	{fakeKustoCommandPattern(`\.show\s+database\s+NAME\s+cslschema`), (*fakeKusto).showDatabaseCslSchema},
+
+	// database principals
+	{fakeKustoCommandPattern(`\.(add|drop)\s+database\s+NAME\s+(\w+)\s*\((.*?)\)(?:\s+STRING)?`), (*fakeKusto).addDropDatabasePrincipals},
+	{fakeKustoCommandPattern(`\.show\s+database\s+NAME\s+principals`), (*fakeKusto).showDatabasePrincipals},
+	{fakeKustoCommandPattern(`\.set\s+(database|table)\s+NAME\s+(\w+)\s+(none|\(.*?\))(?:\s+STRING)?`), (*fakeKusto).setPrincipals},
+
+	// tables
+	{fakeKustoCommandPattern(`\.create\s+table\s+NAME\s*\((.*?)\)\s*(?:with\s*\((.*)\))?`), (*fakeKusto).createTable},
+	{fakeKustoCommandPattern(`\.(alter|alter-merge)\s+table\s+NAME\s*\((.*?)\)\s*(?:with\s*\((.*)\))?`), (*fakeKusto).alterTable},
+	{fakeKustoCommandPattern(`\.show\s+tables\s*(?:\((.*?)\)\s*)?details`), (*fakeKusto).showTablesDetails},
+	{fakeKustoCommandPattern(`\.show\s+table\s+NAME\s+cslschema`), (*fakeKusto).showTableCslSchema},
+	{fakeKustoCommandPattern(`\.drop\s+table\s+NAME(\s+ifexists)?`), (*fakeKusto).dropTable},
+
+	// ingestion mappings
+	{fakeKustoCommandPattern(`\.(?:create|create-or-alter|alter)\s+table\s+NAME\s+ingestion\s+(\w+)\s+mapping\s+STRING\s+STRING`), (*fakeKusto).createMapping},
+	{fakeKustoCommandPattern(`\.show\s+table\s+NAME\s+ingestion\s+(\w+)\s+mapping\s+STRING`), (*fakeKusto).showMapping},
+	{fakeKustoCommandPattern(`\.drop\s+table\s+NAME\s+ingestion\s+(\w+)\s+mapping\s+STRING`), (*fakeKusto).dropMapping},
+
+	// table principals
+	{fakeKustoCommandPattern(`\.(add|drop)\s+table\s+NAME\s+(\w+)\s*\((.*?)\)(?:\s+STRING)?`), (*fakeKusto).addDropTablePrincipals},
+	{fakeKustoCommandPattern(`\.show\s+table\s+NAME\s+principals`), (*fakeKusto).showTablePrincipals},
+
+	// function, materialized view and external table principals
+	{fakeKustoCommandPattern(`\.(add|drop)\s+(function|materialized-view|external\s+table)\s+NAME\s+(\w+)\s*\((.*?)\)(?:\s+STRING)?`), (*fakeKusto).addDropEntityPrincipals},
+	{fakeKustoCommandPattern(`\.show\s+(function|materialized-view|external\s+table)\s+NAME\s+principals`), (*fakeKusto).showEntityPrincipals},
+
+	// functions
+	{fakeKustoCommandPattern(`\.(create|alter|create-or-alter)\s+function\s+(ifnotexists\s+)?(?:with\s*\((.*?)\)\s*)?NAME\s*(\(.*?\))\s*(\{.*\})`), (*fakeKusto).createFunction},
+	{fakeKustoCommandPattern(`\.show\s+functions(?:\s*\|\s*where\s+Name\s*==\s*STRING)?`), (*fakeKusto).showFunctions},
+	{fakeKustoCommandPattern(`\.drop\s+function\s+NAME(\s+ifexists)?`), (*fakeKusto).dropFunction},
+
+	// materialized views
+	{fakeKustoCommandPattern(`\.(create|alter|create-or-alter)(\s+async)?\s+materialized-view\s+(ifnotexists\s+)?(?:with\s*\((.*?)\)\s*)?NAME\s+on\s+table\s+NAME\s*\{(.*)\}`), (*fakeKusto).createMaterializedView},
+	{fakeKustoCommandPattern(`\.show\s+materialized-views(?:\s*\|\s*where\s+Name\s*==\s*STRING)?(?:\s*\|\s*extend\s.*)?`), (*fakeKusto).showMaterializedViews},
+	{fakeKustoCommandPattern(`\.show\s+materialized-views\s*\((.*?)\)\s+details`), (*fakeKusto).showMaterializedViewsDetails},
+	{fakeKustoCommandPattern(`\.drop\s+materialized-view\s+NAME(\s+ifexists)?`), (*fakeKusto).dropMaterializedView},
+	{fakeKustoCommandPattern(`\.show\s+operations\s+([\w-]+)`), (*fakeKusto).showOperation},
+
+	// external tables and continuous exports
+	{fakeKustoCommandPattern(`\.(?:create|alter|create-or-alter)\s+external\s+table\s+NAME\s*\((.*?)\)\s*kind\s*=\s*(\w+)(.*?)\s*dataformat\s*=\s*(\w+)\s*\((.*?)\)\s*(?:with\s*\((.*)\))?`), (*fakeKusto).createExternalTable},
+	{fakeKustoCommandPattern(`\.show\s+external\s+table\s+NAME`), (*fakeKusto).showExternalTable},
+	{fakeKustoCommandPattern(`\.show\s+external\s+tables(?:\s*\|\s*where\s+TableName\s*==\s*STRING)?`), (*fakeKusto).showExternalTables},
+	{fakeKustoCommandPattern(`\.drop\s+external\s+table\s+NAME`), (*fakeKusto).dropExternalTable},
+	{fakeKustoCommandPattern(`\.(?:create|create-or-alter)\s+continuous-export\s+NAME\s+to\s+table\s+NAME\s*(?:with\s*\((.*?)\))?\s*<\|\s*(.*)`), (*fakeKusto).createContinuousExport},
+	{fakeKustoCommandPattern(`\.show\s+continuous-export\s+NAME(?:\s*\|\s*project\s.*)?`), (*fakeKusto).showContinuousExport},
+	{fakeKustoCommandPattern(`\.drop\s+continuous-export\s+NAME`), (*fakeKusto).dropContinuousExport},
+
+	// workload groups
+	{fakeKustoCommandPattern(`\.(?:create|alter|create-or-alter)\s+workload_group\s+NAME\s*(.*)`), (*fakeKusto).createWorkloadGroup},
+	{fakeKustoCommandPattern(`\.show\s+workload_group\s+NAME`), (*fakeKusto).showWorkloadGroup},
+	{fakeKustoCommandPattern(`\.drop\s+workload_group\s+NAME`), (*fakeKusto).dropWorkloadGroup},
+
+	// cluster principals
+	{fakeKustoCommandPattern(`\.(add|drop)\s+cluster\s+(\w+)\s*\((.*?)\)(?:\s+STRING)?`), (*fakeKusto).addDropClusterPrincipals},
+	{fakeKustoCommandPattern(`\.show\s+cluster\s+principals`), (*fakeKusto).showClusterPrincipals},
+
+	// cluster policies
+	{fakeKustoCommandPattern(`\.alter\s+cluster\s+policy\s+request_classification\s+STRING\s*<\|\s*(.*)`), (*fakeKusto).alterRequestClassificationPolicy},
+	{fakeKustoCommandPattern(`\.(alter|alter-merge)\s+cluster\s+policy\s+(\w+)\s+(.*)`), (*fakeKusto).alterClusterPolicy},
+	{fakeKustoCommandPattern(`\.show\s+cluster\s+policy\s+(\w+)`), (*fakeKusto).showClusterPolicy},
+	{fakeKustoCommandPattern(`\.delete\s+cluster\s+policy\s+(\w+)`), (*fakeKusto).deleteClusterPolicy},
+
+	// database, table, materialized view and column policies
+	{fakeKustoCommandPattern(`\.(alter|alter-merge)\s+(?:follower\s+database\s+NAME\s+)?(table|tables|materialized-view|database|column|external\s+table)\s+(\([^)]*\)|\S+)\s+policy\s+(\w+)\s*(.*)`), (*fakeKusto).alterPolicy},
+	{fakeKustoCommandPattern(`\.show\s+(?:follower\s+database\s+NAME\s+)?(table|materialized-view|database|column|external\s+table)\s+(\S+)\s+policy\s+(\w+)`), (*fakeKusto).showPolicy},
+	{fakeKustoCommandPattern(`\.delete\s+(?:follower\s+database\s+NAME\s+)?(table|materialized-view|database|column|external\s+table)\s+(\S+)\s+policy\s+(\w+)`), (*fakeKusto).deletePolicy},
+	{fakeKustoCommandPattern(`\.show\s+(table|materialized-view|database)\s+NAME\s+policy\s+caching\s*\|\s*project\s+Result\s*=\s*tostring\(toint\(totimespan\(todynamic\(Policy\)\.DataHotSpan\.Value\)\s*/\s*1(\w+)\)\)`), (*fakeKusto).showPolicyHotCacheValue},
+	{fakeKustoCommandPattern(`\.(alter|alter-merge)\s+follower\s+database\s+NAME\s+policy\s+(\w+)\s*(.*)`), (*fakeKusto).alterFollowerDatabasePolicy},
+	{fakeKustoCommandPattern(`\.delete\s+follower\s+database\s+NAME\s+policy\s+(\w+)`), (*fakeKusto).deleteFollowerDatabasePolicy},
+}
+
+var fakeKustoQueryHandlers = []fakeKustoHandler{
+	{fakeKustoCommandPattern(`print\s+Result\s*=\s*tostring\(toint\(totimespan\(STRING\)\s*/\s*1(\w+)\)\)`), (*fakeKusto).printTimespan},
+}
+
+// fakeKustoLiteral decodes an optional string literal captured by a STRING pattern
+func fakeKustoLiteral(s string) string {
+	value, _ := fakeKustoDecodeString(s)
+	return value
+}
+
+func (f *fakeKusto) executeMgmt(db *fakeKustoDatabase, csl string) (*fakeKustoResult, error) {
+	return f.execute(fakeKustoMgmtHandlers, db, csl)
+}
+
+func (f *fakeKusto) executeQuery(db *fakeKustoDatabase, csl string) (*fakeKustoResult, error) {
+	if result, ok := f.results[csl]; ok {
+		return result, nil
+	}
+	return f.execute(fakeKustoQueryHandlers, db, csl)
+}
+
+func (f *fakeKusto) execute(handlers []fakeKustoHandler, db *fakeKustoDatabase, csl string) (*fakeKustoResult, error) {
+	for _, h := range handlers {
+		if m := h.pattern.FindStringSubmatch(csl); m != nil {
+			return h.handle(f, db, m)
+		}
+	}
+	return nil, fmt.Errorf("fake cluster does not support the command: %s", csl)
+}
+
+func (f *fakeKusto) showDatabase(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	if m[1] != "" {
+		db = f.database(m[1])
+	}
+	result := newFakeKustoResult("DatabaseName:string", "PrettyName:string")
+	result.addRow(db.reportedName(), db.name)
+	return result, nil
+}
+
+// showDatabaseSchemaJSON reports the schema of the database in the format of .show database schema as json
+func (f *fakeKusto) showDatabaseSchemaJSON(_ *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	db := f.database(m[1])
+
+	tables := map[string]interface{}{}
+	for _, t := range db.tables {
+		tables[t.name] = map[string]interface{}{"Name": t.name, "Folder": t.folder, "DocString": t.docString, "OrderedColumns": fakeKustoSchemaColumns(t.schema)}
+	}
+	externalTables := map[string]interface{}{}
+	for _, t := range db.externalTables {
+		externalTables[t.name] = map[string]interface{}{"Name": t.name, "Folder": t.folder, "DocString": "", "OrderedColumns": fakeKustoSchemaColumns(t.schema)}
+	}
+	views := map[string]interface{}{}
+	for _, v := range db.materializedViews {
+		// the columns of a view are those of its source table, as the fake does not evaluate the query
+		var columns []map[string]interface{}
+		if t, ok := db.tables[v.sourceTable]; ok {
+			columns = fakeKustoSchemaColumns(t.schema)
+		}
+		views[v.name] = map[string]interface{}{"Name": v.name, "Folder": v.folder, "DocString": v.docString, "SourceTable": v.sourceTable, "Query": v.query, "OrderedColumns": columns}
+	}
+	functions := map[string]interface{}{}
+	for _, fn := range db.functions {
+		var parameters []map[string]interface{}
+		for _, p := range fakeKustoSplitTopLevel(strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(fn.parameters), "("), ")"), ',') {
+			if strings.TrimSpace(p) == "" {
+				continue
+			}
+			declaration, defaultValue, hasDefault := strings.Cut(p, "=")
+			name, parameterType, _ := strings.Cut(declaration, ":")
+			parameter := map[string]interface{}{"Name": fakeKustoUnquote(strings.TrimSpace(name)), "CslType": strings.TrimSpace(parameterType), "CslDefaultValue": nil}
+			if hasDefault {
+				parameter["CslDefaultValue"] = strings.TrimSpace(defaultValue)
+			}
+			parameters = append(parameters, parameter)
+		}
+		functions[fn.name] = map[string]interface{}{"Name": fn.name, "Folder": fn.folder, "DocString": fn.docString, "Body": fn.body, "InputParameters": parameters}
+	}
+
+	schema, _ := json.Marshal(map[string]interface{}{
+		"Databases": map[string]interface{}{
+			db.reportedName(): map[string]interface{}{
+				"Name":              db.reportedName(),
+				"Tables":            tables,
+				"ExternalTables":    externalTables,
+				"MaterializedViews": views,
+				"Functions":         functions,
+			},
+		},
+	})
+	result := newFakeKustoResult("DatabaseSchema:string")
+	result.addRow(string(schema))
+	return result, nil
+}
+
+// fakeKustoSchemaColumns lists the columns of an inline schema such as "f1:string,f2:int"
+func fakeKustoSchemaColumns(schema string) []map[string]interface{} {
+	columns := make([]map[string]interface{}, 0)
+	for _, column := range fakeKustoSplitTopLevel(schema, ',') {
+		name, columnType, _ := strings.Cut(column, ":")
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+		columns = append(columns, map[string]interface{}{"Name": fakeKustoUnquote(strings.TrimSpace(name)), "CslType": strings.TrimSpace(columnType), "DocString": ""})
+	}
+	return columns
+}
+
+func (f *fakeKusto) addDropDatabasePrincipals(_ *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	db := f.database(m[2])
+	role := strings.ToLower(m[3])
+	principals := fakeKustoParseList(m[4])
+	var err error
+	if strings.ToLower(m[1]) == "add" {
+		if db.principals, err = addPrincipals(db.principals, role, principals, fakeKustoLiteral(m[5])); err != nil {
+			return nil, err
+		}
+	} else {
+		db.principals = dropPrincipals(db.principals, role, principals)
+	}
+	return principalsResult(db.principals, "Database"), nil
+}
+
+func (f *fakeKusto) showDatabasePrincipals(_ *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	return principalsResult(f.database(m[1]).principals, "Database"), nil
+}
+
+// setPrincipals replaces all principals of a database or table role, as .set does
+func (f *fakeKusto) setPrincipals(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	principals, entityKind := &f.database(m[2]).principals, "Database"
+	if strings.ToLower(m[1]) == "table" {
+		t, err := db.table(m[2])
+		if err != nil {
+			return nil, err
+		}
+		principals, entityKind = &t.principals, "Table"
+	}
+	role := strings.ToLower(m[3])
+	var fqns []string
+	if strings.ToLower(m[4]) != "none" {
+		fqns = fakeKustoParseList(m[4])
+	}
+
+	var kept []fakeKustoPrincipal
+	for _, p := range *principals {
+		if p.role != role {
+			kept = append(kept, p)
+		}
+	}
+	updated, err := addPrincipals(kept, role, fqns, fakeKustoLiteral(m[5]))
+	if err != nil {
+		return nil, err
+	}
+	*principals = updated
+	return principalsResult(updated, entityKind), nil
+}
+
+func tableSchemaResult(db *fakeKustoDatabase, tables ...*fakeKustoTable) *fakeKustoResult {
+	result := newFakeKustoResult("TableName:string", "Schema:string", "DatabaseName:string", "Folder:string", "DocString:string")
+	for _, t := range tables {
+		result.addRow(t.name, t.schema, db.name, t.folder, t.docString)
+	}
+	return result
+}
+
+func (f *fakeKusto) createTable(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	// .create table succeeds without changes when the table already exists
+	t, err := db.table(m[1])
+	if err != nil {
+		t = db.addTable(fakeKustoUnquote(m[1]), fakeKustoNormalizeSchema(m[2]))
+		properties := fakeKustoParseProperties(m[3])
+		t.folder = properties["folder"]
+		t.docString = properties["docstring"]
+	}
+	return tableSchemaResult(db, t), nil
+}
+
+func (f *fakeKusto) alterTable(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	t, err := db.table(m[2])
+	if err != nil {
+		return nil, err
+	}
+	schema := fakeKustoNormalizeSchema(m[3])
+	if strings.ToLower(m[1]) == "alter-merge" {
+		t.mergeSchema(schema)
+	} else {
+		t.schema = schema
+	}
+	properties := fakeKustoParseProperties(m[4])
+	if folder, ok := properties["folder"]; ok {
+		t.folder = folder
+	}
+	if docString, ok := properties["docstring"]; ok {
+		t.docString = docString
+	}
+	return tableSchemaResult(db, t), nil
+}
+
+// showTablesDetails lists the given tables, all tables when none are given
+func (f *fakeKusto) showTablesDetails(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	names := fakeKustoParseList(m[1])
+	if m[1] == "" {
+		names = sortedKeys(db.tables)
+	}
+	result := newFakeKustoResult("TableName:string", "DatabaseName:string", "Folder:string", "DocString:string",
+		"TotalExtents:long", "TotalExtentSize:real", "TotalOriginalSize:real", "TotalRowCount:long",
+		"HotExtents:long", "HotExtentSize:real", "HotRowCount:long",
+		"MinExtentsCreationTime:datetime", "MaxExtentsCreationTime:datetime")
+	for _, name := range names {
+		if t, ok := db.tables[name]; ok {
+			// all extents are hot, their sizes are derived from the row count
+			var minCreated, maxCreated interface{}
+			if t.extents > 0 {
+				minCreated, maxCreated = fakeKustoExtentsCreationTime, fakeKustoExtentsCreationTime
+			}
+			result.addRow(t.name, db.name, t.folder, t.docString,
+				t.extents, float64(t.rowCount*64), float64(t.rowCount*256), t.rowCount,
+				t.extents, float64(t.rowCount*64), t.rowCount,
+				minCreated, maxCreated)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeKusto) showTableCslSchema(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	t, err := db.table(m[1])
+	if err != nil {
+		return nil, err
+	}
+	return tableSchemaResult(db, t), nil
+}
+
+// showDatabaseCslSchema reports the schema of every table of the database
+func (f *fakeKusto) showDatabaseCslSchema(_ *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	db := f.database(m[1])
+	result := newFakeKustoResult("TableName:string", "Schema:string", "DatabaseName:string", "Folder:string", "DocString:string")
+	for _, name := range sortedKeys(db.tables) {
+		t := db.tables[name]
+		result.addRow(t.name, t.schema, db.reportedName(), t.folder, t.docString)
+	}
+	return result, nil
+}
+
+func (f *fakeKusto) dropTable(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	t, err := db.table(m[1])
+	if err != nil {
+		if m[2] != "" {
+			return newFakeKustoResult("TableName:string", "DatabaseName:string"), nil
+		}
+		return nil, err
+	}
+	delete(db.tables, t.name)
+	db.deletePolicies("table", t.name)
+
+	result := newFakeKustoResult("TableName:string", "DatabaseName:string")
+	for _, name := range sortedKeys(db.tables) {
+		result.addRow(name, db.name)
+	}
+	return result, nil
+}
+
+// deletePolicies removes the policies of a dropped entity
+func (db *fakeKustoDatabase) deletePolicies(entityType string, entityName string) {
+	for key := range db.policies {
+		if strings.HasPrefix(key, db.policyKey(entityType, entityName, "")) {
+			delete(db.policies, key)
+		}
+	}
+}
+
+func mappingResult(db *fakeKustoDatabase, t *fakeKustoTable, mappings ...*fakeKustoMapping) *fakeKustoResult {
+	result := newFakeKustoResult("Name:string", "Kind:string", "Mapping:string", "LastUpdatedOn:datetime", "Database:string", "Table:string")
+	for _, mapping := range mappings {
+		result.addRow(mapping.name, mapping.kind, mapping.mapping, mapping.lastUpdatedOn.Format(time.RFC3339Nano), db.name, t.name)
+	}
+	return result
+}
+
+func (f *fakeKusto) createMapping(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	t, err := db.table(m[1])
+	if err != nil {
+		return nil, err
+	}
+	name, definition := fakeKustoLiteral(m[3]), fakeKustoLiteral(m[4])
+	var columns []map[string]interface{}
+	if err := json.Unmarshal([]byte(definition), &columns); err != nil {
+		return nil, fmt.Errorf("invalid ingestion mapping: %+v", err)
+	}
+	mapping := &fakeKustoMapping{
+		name:          name,
+		kind:          fakeKustoCapitalize(m[2]),
+		mapping:       definition,
+		lastUpdatedOn: time.Now().UTC(),
+	}
+	t.mappings[strings.ToLower(m[2])+"|"+name] = mapping
+	return mappingResult(db, t, mapping), nil
+}
+
+func (f *fakeKusto) showMapping(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	t, err := db.table(m[1])
+	if err != nil {
+		return nil, err
+	}
+	name := fakeKustoLiteral(m[3])
+	mapping, ok := t.mappings[strings.ToLower(m[2])+"|"+name]
+	if !ok {
+		return nil, fakeKustoEntityNotFound("Ingestion mapping '%s' was not found", name)
+	}
+	return mappingResult(db, t, mapping), nil
+}
+
+func (f *fakeKusto) dropMapping(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	t, err := db.table(m[1])
+	if err != nil {
+		return nil, err
+	}
+	name := fakeKustoLiteral(m[3])
+	key := strings.ToLower(m[2]) + "|" + name
+	if _, ok := t.mappings[key]; !ok {
+		return nil, fakeKustoEntityNotFound("Ingestion mapping '%s' was not found", name)
+	}
+	delete(t.mappings, key)
+	return mappingResult(db, t), nil
+}
+
+func (f *fakeKusto) addDropTablePrincipals(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	t, err := db.table(m[2])
+	if err != nil {
+		return nil, err
+	}
+	role := strings.ToLower(m[3])
+	if role != "admins" && role != "ingestors" {
+		return nil, fmt.Errorf("role '%s' is not supported for tables", m[3])
+	}
+	principals := fakeKustoParseList(m[4])
+	if strings.ToLower(m[1]) == "add" {
+		if t.principals, err = addPrincipals(t.principals, role, principals, fakeKustoLiteral(m[5])); err != nil {
+			return nil, err
+		}
+	} else {
+		t.principals = dropPrincipals(t.principals, role, principals)
+	}
+	return principalsResult(t.principals, "Table"), nil
+}
+
+func (f *fakeKusto) showTablePrincipals(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	t, err := db.table(m[1])
+	if err != nil {
+		return nil, err
+	}
+	return principalsResult(t.principals, "Table"), nil
+}
+
+func (f *fakeKusto) addDropEntityPrincipals(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	principals, entityKind, err := db.entityPrincipals(m[2], m[3])
+	if err != nil {
+		return nil, err
+	}
+	role := strings.ToLower(m[4])
+	if role != "admins" {
+		return nil, fmt.Errorf("role '%s' is not supported for %s", m[4], m[2])
+	}
+	fqns := fakeKustoParseList(m[5])
+	if strings.ToLower(m[1]) == "add" {
+		if *principals, err = addPrincipals(*principals, role, fqns, fakeKustoLiteral(m[6])); err != nil {
+			return nil, err
+		}
+	} else {
+		*principals = dropPrincipals(*principals, role, fqns)
+	}
+	return principalsResult(*principals, entityKind), nil
+}
+
+func (f *fakeKusto) showEntityPrincipals(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	principals, entityKind, err := db.entityPrincipals(m[1], m[2])
+	if err != nil {
+		return nil, err
+	}
+	return principalsResult(*principals, entityKind), nil
+}
+
+func (f *fakeKusto) addDropClusterPrincipals(_ *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	role := strings.ToLower(m[2])
+	if !strings.HasPrefix(role, "alldatabases") {
+		return nil, fmt.Errorf("role '%s' is not supported for the cluster", m[2])
+	}
+	fqns := fakeKustoParseList(m[3])
+	var err error
+	if strings.ToLower(m[1]) == "add" {
+		if f.cluster.principals, err = addPrincipals(f.cluster.principals, role, fqns, fakeKustoLiteral(m[4])); err != nil {
+			return nil, err
+		}
+	} else {
+		f.cluster.principals = dropPrincipals(f.cluster.principals, role, fqns)
+	}
+	return principalsResult(f.cluster.principals, ""), nil
+}
+
+func (f *fakeKusto) showClusterPrincipals(_ *fakeKustoDatabase, _ []string) (*fakeKustoResult, error) {
+	return principalsResult(f.cluster.principals, ""), nil
+}
+
+func functionsResult(functions ...*fakeKustoFunction) *fakeKustoResult {
+	result := newFakeKustoResult("Name:string", "Parameters:string", "Body:string", "Folder:string", "DocString:string")
+	for _, fn := range functions {
+		result.addRow(fn.name, fn.parameters, fn.body, fn.folder, fn.docString)
+	}
+	return result
+}
+
+func (f *fakeKusto) createFunction(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	cmd := strings.ToLower(m[1])
+	name := fakeKustoUnquote(m[4])
+	fn, exists := db.functions[name]
+	switch {
+	case cmd == "create" && exists && m[2] != "":
+		return functionsResult(fn), nil
+	case cmd == "create" && exists:
+		return nil, &fakeKustoError{Code: "BadRequest_EntityNameAlreadyExists", Message: fmt.Sprintf("Function '%s' already exists", name)}
+	case cmd == "alter" && !exists:
+		return nil, fakeKustoEntityNotFound("Function '%s' was not found", name)
+	}
+
+	properties := fakeKustoParseProperties(m[3])
+	fn = &fakeKustoFunction{
+		name:       name,
+		parameters: m[5],
+		body:       m[6],
+		folder:     properties["folder"],
+		docString:  properties["docstring"],
+	}
+	db.functions[name] = fn
+	return functionsResult(fn), nil
+}
+
+func (f *fakeKusto) showFunctions(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	var functions []*fakeKustoFunction
+	filter := fakeKustoLiteral(m[1])
+	for _, name := range sortedKeys(db.functions) {
+		if filter == "" || filter == name {
+			functions = append(functions, db.functions[name])
+		}
+	}
+	return functionsResult(functions...), nil
+}
+
+func (f *fakeKusto) dropFunction(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	name := fakeKustoUnquote(m[1])
+	if _, ok := db.functions[name]; !ok && m[2] == "" {
+		return nil, fakeKustoEntityNotFound("Function '%s' was not found", name)
+	}
+	delete(db.functions, name)
+	return newFakeKustoResult("Name:string"), nil
+}
+
+func materializedViewsResult(views ...*fakeKustoMaterializedView) *fakeKustoResult {
+	// the shape produced by the resource's `| extend` projection, which turns flags into strings
+	result := newFakeKustoResult("Name:string", "SourceTable:string", "Query:string", "MaterializedTo:datetime", "AutoUpdateSchema:string",
+		"EffectiveDateTime:datetime", "Lookback:string", "Folder:string", "DocString:string", "IsHealthy:string", "IsEnabled:string")
+	for _, v := range views {
+		result.addRow(v.name, v.sourceTable, v.query, v.effectiveDateTime.Format(time.RFC3339Nano), strconv.FormatBool(v.autoUpdateSchema),
+			v.effectiveDateTime.Format(time.RFC3339Nano), "", v.folder, v.docString, "true", "true")
+	}
+	return result
+}
+
+func (f *fakeKusto) createMaterializedView(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	cmd := strings.ToLower(m[1])
+	name := fakeKustoUnquote(m[5])
+	view, exists := db.materializedViews[name]
+	switch {
+	case cmd == "create" && exists && m[3] != "":
+		return materializedViewsResult(view), nil
+	case cmd == "create" && exists:
+		return nil, &fakeKustoError{Code: "BadRequest_EntityNameAlreadyExists", Message: fmt.Sprintf("Materialized view '%s' already exists", name)}
+	case cmd == "alter" && !exists:
+		return nil, fakeKustoEntityNotFound("Materialized view '%s' was not found", name)
+	}
+	sourceTable, err := db.table(m[6])
+	if err != nil {
+		return nil, err
+	}
+
+	properties := fakeKustoParseProperties(m[4])
+	if !exists {
+		view = &fakeKustoMaterializedView{name: name, effectiveDateTime: time.Now().UTC()}
+		if effectiveDateTime, ok := properties["effectivedatetime"]; ok {
+			if view.effectiveDateTime, err = time.Parse(time.RFC3339, effectiveDateTime); err != nil {
+				return nil, fmt.Errorf("invalid effectiveDateTime: %+v", err)
+			}
+		}
+	}
+	view.sourceTable = sourceTable.name
+	view.query = strings.TrimSpace(m[7])
+	view.folder = properties["folder"]
+	view.docString = properties["docstring"]
+	view.autoUpdateSchema = strings.EqualFold(properties["autoupdateschema"], "true")
+	db.materializedViews[name] = view
+
+	if m[2] != "" {
+		result := newFakeKustoResult("OperationId:guid")
+		result.addRow(fakeKustoObjectID(db.name + "|" + name + "|" + time.Now().String()))
+		return result, nil
+	}
+	return materializedViewsResult(view), nil
+}
+
+func (f *fakeKusto) showMaterializedViews(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	var views []*fakeKustoMaterializedView
+	filter := fakeKustoLiteral(m[1])
+	for _, name := range sortedKeys(db.materializedViews) {
+		if filter == "" || filter == name {
+			views = append(views, db.materializedViews[name])
+		}
+	}
+	return materializedViewsResult(views...), nil
+}
+
+func (f *fakeKusto) showMaterializedViewsDetails(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	result := newFakeKustoResult("MaterializedViewName:string", "DatabaseName:string", "Folder:string", "DocString:string")
+	for _, name := range fakeKustoParseList(m[1]) {
+		if v, ok := db.materializedViews[name]; ok {
+			result.addRow(v.name, db.name, v.folder, v.docString)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeKusto) dropMaterializedView(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	name := fakeKustoUnquote(m[1])
+	if _, ok := db.materializedViews[name]; !ok && m[2] == "" {
+		return nil, fakeKustoEntityNotFound("Materialized view '%s' was not found", name)
+	}
+	delete(db.materializedViews, name)
+	db.deletePolicies("materialized-view", name)
+	return newFakeKustoResult("Name:string"), nil
+}
+
+// showOperation reports every async operation as completed, the fake cluster applies commands synchronously
+func (f *fakeKusto) showOperation(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+	result := newFakeKustoResult("OperationId:guid", "Operation:string", "NodeId:string", "StartedOn:datetime", "LastUpdatedOn:datetime",
+		"Duration:timespan", "State:string", "Status:string")
+	result.addRow(m[1], "", "", now, now, "00:00:00", "Completed", "")
+	return result, nil
+}
+
+var fakeKustoExternalTablePartsPattern = regexp.MustCompile(`(?is)^\s*(?:partition\s+by\s*\((.*?)\))?\s*(?:pathformat\s*=\s*\((.*)\))?\s*$`)
+
+// externalTableResult reports partitions, path format and connection strings as they were sent, rather
+// than normalising and obfuscating them the way the cluster does
+func externalTableResult(tables ...*fakeKustoExternalTable) *fakeKustoResult {
+	result := newFakeKustoResult("Name:string", "ConnectionStrings:string", "Partitions:string", "PathFormat:string", "Folder:string", "Properties:string")
+	for _, t := range tables {
+		result.addRow(t.name, t.connectionStrings, t.partitions, t.pathFormat, t.folder, t.properties)
+	}
+	return result
+}
+
+func (f *fakeKusto) createExternalTable(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	parts := fakeKustoExternalTablePartsPattern.FindStringSubmatch(m[4])
+	if parts == nil {
+		return nil, fmt.Errorf("invalid external table definition: %s", m[4])
+	}
+	properties := fakeKustoParseProperties(m[7])
+	t := &fakeKustoExternalTable{
+		name:              fakeKustoUnquote(m[1]),
+		schema:            m[2],
+		kind:              m[3],
+		partitions:        parts[1],
+		pathFormat:        parts[2],
+		dataFormat:        m[5],
+		connectionStrings: strings.Join(fakeKustoParseList(m[6]), ","),
+		folder:            properties["folder"],
+	}
+	delete(properties, "folder")
+	if len(properties) > 0 {
+		encoded, _ := json.Marshal(properties)
+		t.properties = string(encoded)
+	}
+	db.externalTables[t.name] = t
+	return externalTableResult(t), nil
+}
+
+func (f *fakeKusto) showExternalTables(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	result := newFakeKustoResult("TableName:string", "Folder:string", "DocString:string")
+	name := fakeKustoLiteral(m[1])
+	for _, t := range db.externalTables {
+		if m[1] == "" || t.name == name {
+			result.addRow(t.name, t.folder, "")
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeKusto) showExternalTable(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	t, ok := db.externalTables[fakeKustoUnquote(m[1])]
+	if !ok {
+		return nil, fakeKustoEntityNotFound("External table '%s' was not found", fakeKustoUnquote(m[1]))
+	}
+	return externalTableResult(t), nil
+}
+
+func (f *fakeKusto) dropExternalTable(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	name := fakeKustoUnquote(m[1])
+	if _, ok := db.externalTables[name]; !ok {
+		return nil, fakeKustoEntityNotFound("External table '%s' was not found", name)
+	}
+	delete(db.externalTables, name)
+	return newFakeKustoResult("TableName:string"), nil
+}
+
+func continuousExportResult(exports ...*fakeKustoContinuousExport) *fakeKustoResult {
+	result := newFakeKustoResult("Name:string", "ExternalTableName:string", "Query:string")
+	for _, e := range exports {
+		result.addRow(e.name, e.externalTableName, e.query)
+	}
+	return result
+}
+
+func (f *fakeKusto) createContinuousExport(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	externalTableName := fakeKustoUnquote(m[2])
+	if _, ok := db.externalTables[externalTableName]; !ok {
+		return nil, fakeKustoEntityNotFound("External table '%s' was not found", externalTableName)
+	}
+	e := &fakeKustoContinuousExport{
+		name:              fakeKustoUnquote(m[1]),
+		externalTableName: externalTableName,
+		query:             strings.TrimSpace(m[4]),
+	}
+	db.continuousExports[e.name] = e
+	return continuousExportResult(e), nil
+}
+
+func (f *fakeKusto) showContinuousExport(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	e, ok := db.continuousExports[fakeKustoUnquote(m[1])]
+	if !ok {
+		return nil, fakeKustoEntityNotFound("Continuous export '%s' was not found", fakeKustoUnquote(m[1]))
+	}
+	return continuousExportResult(e), nil
+}
+
+func (f *fakeKusto) dropContinuousExport(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	name := fakeKustoUnquote(m[1])
+	if _, ok := db.continuousExports[name]; !ok {
+		return nil, fakeKustoEntityNotFound("Continuous export '%s' was not found", name)
+	}
+	delete(db.continuousExports, name)
+	return continuousExportResult(), nil
+}
+
+func workloadGroupResult(name string, policy string) *fakeKustoResult {
+	result := newFakeKustoResult("WorkloadGroupName:string", "WorkloadGroup:string")
+	if policy != "" {
+		result.addRow(name, policy)
+	}
+	return result
+}
+
+func (f *fakeKusto) createWorkloadGroup(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	name := fakeKustoUnquote(m[1])
+	policy, err := fakeKustoPolicyLiteral(m[2])
+	if err != nil || !json.Valid([]byte(policy)) {
+		return nil, fmt.Errorf("invalid workload group policy: %s", policy)
+	}
+	f.cluster.workloadGroups[name] = policy
+	return workloadGroupResult(name, policy), nil
+}
+
+func (f *fakeKusto) showWorkloadGroup(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	name := fakeKustoUnquote(m[1])
+	return workloadGroupResult(name, f.cluster.workloadGroups[name]), nil
+}
+
+func (f *fakeKusto) dropWorkloadGroup(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	name := fakeKustoUnquote(m[1])
+	if _, ok := f.cluster.workloadGroups[name]; !ok {
+		return nil, fakeKustoEntityNotFound("Workload group '%s' was not found", name)
+	}
+	delete(f.cluster.workloadGroups, name)
+	return workloadGroupResult(name, ""), nil
+}
+
+// policyResult formats a policy as .show <entity> policy <policy> does, policy is "null" when not set
+func policyResult(policyName string, entityName string, entityType string, policy string) *fakeKustoResult {
+	if policy == "" {
+		policy = "null"
+	}
+	result := newFakeKustoResult("PolicyName:string", "EntityName:string", "Policy:string", "ChildEntities:string", "EntityType:string")
+	result.addRow(fakeKustoPolicyDisplayName(policyName), entityName, policy, "", entityType)
+	return result
+}
+
+// fakeKustoCapitalize formats enum values the way the cluster reports them, e.g. json as Json
+func fakeKustoCapitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + strings.ToLower(s[1:])
+}
+
+// fakeKustoPolicyDisplayName maps a policy command name such as row_level_security to RowLevelSecurityPolicy
+func fakeKustoPolicyDisplayName(policyName string) string {
+	var name string
+	for _, part := range strings.Split(policyName, "_") {
+		if part != "" {
+			name += strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return name + "Policy"
+}
+
+func (f *fakeKusto) alterRequestClassificationPolicy(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	var policy map[string]interface{}
+	if err := json.Unmarshal([]byte(fakeKustoLiteral(m[1])), &policy); err != nil {
+		return nil, fmt.Errorf("invalid request classification policy: %+v", err)
+	}
+	policy["ClassificationFunction"] = strings.TrimSpace(m[2])
+	encoded, _ := json.Marshal(policy)
+	f.cluster.policies["request_classification"] = string(encoded)
+	return policyResult("request_classification", "", "Cluster", string(encoded)), nil
+}
+
+func (f *fakeKusto) alterClusterPolicy(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	policyName := strings.ToLower(m[2])
+	policy, err := fakeKustoPolicyFromCommand(policyName, m[3], f.cluster.policies[policyName], strings.ToLower(m[1]) == "alter-merge")
+	if err != nil {
+		return nil, err
+	}
+	f.cluster.policies[policyName] = policy
+	return policyResult(policyName, "", "Cluster", policy), nil
+}
+
+func (f *fakeKusto) showClusterPolicy(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	policyName := strings.ToLower(m[1])
+	return policyResult(policyName, "", "Cluster", f.cluster.policies[policyName]), nil
+}
+
+func (f *fakeKusto) deleteClusterPolicy(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	policyName := strings.ToLower(m[1])
+	delete(f.cluster.policies, policyName)
+	return policyResult(policyName, "", "Cluster", ""), nil
+}
+
+// policyEntities resolves the entities a policy command applies to, checking that they exist
+func (f *fakeKusto) policyEntities(db *fakeKustoDatabase, entityType string, entityName string) (string, []string, error) {
+	switch entityType {
+	case "tables":
+		names := fakeKustoParseList(entityName)
+		for _, name := range names {
+			if _, err := db.table(name); err != nil {
+				return "", nil, err
+			}
+		}
+		return "table", names, nil
+	case "table":
+		t, err := db.table(entityName)
+		if err != nil {
+			return "", nil, err
+		}
+		return entityType, []string{t.name}, nil
+	case "materialized-view":
+		name := fakeKustoUnquote(entityName)
+		if _, ok := db.materializedViews[name]; !ok {
+			return "", nil, fakeKustoEntityNotFound("Materialized view '%s' was not found", name)
+		}
+		return entityType, []string{name}, nil
+	case "column":
+		i := strings.LastIndex(entityName, ".")
+		if i < 0 {
+			return "", nil, fmt.Errorf("invalid column reference '%s'", entityName)
+		}
+		t, err := db.table(entityName[:i])
+		if err != nil {
+			return "", nil, err
+		}
+		column := fakeKustoUnquote(entityName[i+1:])
+		for _, c := range t.columns() {
+			if c == column {
+				return entityType, []string{t.name + "." + column}, nil
+			}
+		}
+		return "", nil, fakeKustoEntityNotFound("Column '%s' was not found in table '%s'", column, t.name)
+	case "external table":
+		name := fakeKustoUnquote(entityName)
+		if _, ok := db.externalTables[name]; !ok {
+			return "", nil, fakeKustoEntityNotFound("External table '%s' was not found", name)
+		}
+		return entityType, []string{name}, nil
+	default:
+		return entityType, []string{fakeKustoUnquote(entityName)}, nil
+	}
+}
+
+func (f *fakeKusto) alterPolicy(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	if m[2] != "" {
+		db = f.database(m[2])
+	}
+	merge := strings.ToLower(m[1]) == "alter-merge"
+	policyName := strings.ToLower(m[5])
+	entityType, names, err := f.policyEntities(db, strings.ToLower(m[3]), m[4])
+	if err != nil {
+		return nil, err
+	}
+
+	var result *fakeKustoResult
+	for _, name := range names {
+		key := db.policyKey(entityType, name, policyName)
+		policy, err := fakeKustoPolicyFromCommand(policyName, m[6], db.policies[key], merge)
+		if err != nil {
+			return nil, err
+		}
+		if policy == "" {
+			delete(db.policies, key)
+		} else {
+			db.policies[key] = policy
+		}
+		result = policyResult(policyName, name, entityType, policy)
+	}
+	return result, nil
+}
+
+// showPolicyHotCacheValue returns the hot span of a caching policy in the requested unit, as polled after
+// altering the caching policy of a follower database
+func (f *fakeKusto) showPolicyHotCacheValue(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	entityType, names, err := f.policyEntities(db, strings.ToLower(m[1]), m[2])
+	if err != nil {
+		return nil, err
+	}
+	var policy struct {
+		DataHotSpan struct{ Value string }
+	}
+	if err := json.Unmarshal([]byte(db.policies[db.policyKey(entityType, names[0], "caching")]), &policy); err != nil {
+		return nil, fmt.Errorf("no caching policy for %s '%s'", entityType, names[0])
+	}
+	hot, err := parseFakeKustoTimespan(policy.DataHotSpan.Value)
+	if err != nil {
+		return nil, err
+	}
+	unit, err := parseFakeKustoTimespan("1" + m[3])
+	if err != nil {
+		return nil, err
+	}
+	result := newFakeKustoResult("Result:string")
+	result.addRow(strconv.FormatInt(int64(hot/unit), 10))
+	return result, nil
+}
+
+// alterFollowerDatabasePolicy handles .alter follower database D policy ..., which addresses the policy of the
+// follower database itself
+func (f *fakeKusto) alterFollowerDatabasePolicy(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	return f.alterPolicy(db, []string{m[0], m[1], m[2], "database", m[2], m[3], m[4]})
+}
+
+func (f *fakeKusto) deleteFollowerDatabasePolicy(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	return f.deletePolicy(db, []string{m[0], m[1], "database", m[1], m[2]})
+}
+
+func (f *fakeKusto) showPolicy(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	if m[1] != "" {
+		db = f.database(m[1])
+	}
+	policyName := strings.ToLower(m[4])
+	entityType, names, err := f.policyEntities(db, strings.ToLower(m[2]), m[3])
+	if err != nil {
+		return nil, err
+	}
+	return policyResult(policyName, names[0], entityType, db.policies[db.policyKey(entityType, names[0], policyName)]), nil
+}
+
+func (f *fakeKusto) deletePolicy(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	if m[1] != "" {
+		db = f.database(m[1])
+	}
+	policyName := strings.ToLower(m[4])
+	entityType, names, err := f.policyEntities(db, strings.ToLower(m[2]), m[3])
+	if err != nil {
+		return nil, err
+	}
+	delete(db.policies, db.policyKey(entityType, names[0], policyName))
+	return policyResult(policyName, names[0], entityType, ""), nil
+}
+
+var (
+	fakeKustoCachingPattern          = regexp.MustCompile(`(?i)\bhot\s*=\s*(\S+)`)
+	fakeKustoSoftDeletePattern       = regexp.MustCompile(`(?i)\bsoftdelete\s*=\s*(\S+)`)
+	fakeKustoRecoverabilityPattern   = regexp.MustCompile(`(?i)\brecoverability\s*=\s*(\w+)`)
+	fakeKustoBoolPattern             = regexp.MustCompile(`(?i)^(true|false)$`)
+	fakeKustoRowLevelSecurityPattern = regexp.MustCompile(`(?is)^(enable|disable)\s*(?:with\s*\(.*?\))?\s*(` + fakeKustoString + `)$`)
+	fakeKustoEncodingPattern         = regexp.MustCompile(`(?i)\btype\s*=\s*'(\w+)'`)
+)
+
+// fakeKustoPolicyFromCommand turns the arguments of a policy command into the policy JSON the cluster
+// stores. An empty result means the policy was reset.
+func fakeKustoPolicyFromCommand(policyName string, args string, existing string, merge bool) (string, error) {
+	args = strings.TrimSpace(args)
+	policy := map[string]interface{}{}
+	if merge && existing != "" {
+		json.Unmarshal([]byte(existing), &policy)
+	}
+
+	// every policy can also be given as JSON, as the generic adx_policy resource does
+	if literal, err := fakeKustoPolicyLiteral(args); err == nil && json.Valid([]byte(literal)) {
+		if keys, ok := fakeKustoPolicyEntryKeys[policyName]; ok && merge {
+			return fakeKustoMergePolicyEntries(policyName, literal, existing, keys)
+		}
+		return fakeKustoJSONPolicy(policyName, literal, policy, merge)
+	}
+
+	switch policyName {
+	case "caching":
+		m := fakeKustoCachingPattern.FindStringSubmatch(args)
+		if m == nil {
+			return "", fmt.Errorf("invalid caching policy: %s", args)
+		}
+		hot, err := parseFakeKustoTimespan(m[1])
+		if err != nil {
+			return "", err
+		}
+		policy["DataHotSpan"] = map[string]string{"Value": formatFakeKustoTimespan(hot)}
+		policy["IndexHotSpan"] = map[string]string{"Value": formatFakeKustoTimespan(hot)}
+	case "retention":
+		if _, ok := policy["Recoverability"]; !ok {
+			policy["Recoverability"] = "Enabled"
+		}
+		if m := fakeKustoSoftDeletePattern.FindStringSubmatch(args); m != nil {
+			softDelete, err := parseFakeKustoTimespan(m[1])
+			if err != nil {
+				return "", err
+			}
+			policy["SoftDeletePeriod"] = formatFakeKustoTimespan(softDelete)
+		}
+		if m := fakeKustoRecoverabilityPattern.FindStringSubmatch(args); m != nil {
+			policy["Recoverability"] = fakeKustoCapitalize(m[1])
+		}
+	case "ingestiontime", "restricted_view_access":
+		m := fakeKustoBoolPattern.FindStringSubmatch(args)
+		if m == nil {
+			return "", fmt.Errorf("invalid %s policy: %s", policyName, args)
+		}
+		policy = map[string]interface{}{"IsEnabled": strings.EqualFold(m[1], "true")}
+	case "row_level_security":
+		m := fakeKustoRowLevelSecurityPattern.FindStringSubmatch(args)
+		if m == nil {
+			return "", fmt.Errorf("invalid row_level_security policy: %s", args)
+		}
+		query, _ := fakeKustoDecodeString(m[2])
+		policy = map[string]interface{}{"IsEnabled": strings.EqualFold(m[1], "enable"), "Query": query}
+	case "roworder":
+		if !strings.HasPrefix(args, "(") || !strings.HasSuffix(args, ")") {
+			return "", fmt.Errorf("invalid roworder policy: %s", args)
+		}
+		keys := fakeKustoParseList(args)
+		if len(keys) == 0 {
+			return "", fmt.Errorf("invalid roworder policy: %s", args)
+		}
+		policy = map[string]interface{}{"KeyColumns": keys}
+	case "encoding":
+		m := fakeKustoEncodingPattern.FindStringSubmatch(args)
+		if m == nil {
+			return "", fmt.Errorf("invalid encoding policy: %s", args)
+		}
+		if strings.EqualFold(m[1], "null") {
+			return "", nil
+		}
+		policy = map[string]interface{}{"Profile": m[1]}
+	default:
+		literal, err := fakeKustoPolicyLiteral(args)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s policy: %+v", policyName, err)
+		}
+		return fakeKustoJSONPolicy(policyName, literal, policy, merge)
+	}
+
+	encoded, _ := json.Marshal(policy)
+	return string(encoded), nil
+}
+
+// fakeKustoJSONPolicy stores a policy given as JSON, .alter-merge merges its properties into the existing policy
+func fakeKustoJSONPolicy(policyName string, literal string, existing map[string]interface{}, merge bool) (string, error) {
+	var value interface{}
+	if err := json.Unmarshal([]byte(literal), &value); err != nil {
+		return "", fmt.Errorf("invalid %s policy: %+v", policyName, err)
+	}
+	if object, ok := value.(map[string]interface{}); ok && merge {
+		for k, v := range object {
+			existing[k] = v
+		}
+		value = existing
+	}
+	encoded, _ := json.Marshal(value)
+	return string(encoded), nil
+}
+
+// fakeKustoPolicyEntryKeys lists the properties identifying the entries of policies that are lists of entries
+var fakeKustoPolicyEntryKeys = map[string][]string{
+	"callout": {"CalloutType", "CalloutUriRegex"},
+	"sandbox": {"SandboxKind"},
+}
+
+// fakeKustoMergePolicyEntries merges the entries of a policy that is a list, replacing existing entries with the same key
+func fakeKustoMergePolicyEntries(policyName string, literal string, existing string, keys []string) (string, error) {
+	var entries []map[string]interface{}
+	if err := json.Unmarshal([]byte(literal), &entries); err != nil {
+		return "", fmt.Errorf("invalid %s policy: %+v", policyName, err)
+	}
+	var merged []map[string]interface{}
+	if existing != "" {
+		json.Unmarshal([]byte(existing), &merged)
+	}
+
+	entryKey := func(entry map[string]interface{}) string {
+		var parts []string
+		for _, k := range keys {
+			parts = append(parts, strings.ToLower(fmt.Sprint(entry[k])))
+		}
+		return strings.Join(parts, "|")
+	}
+	for _, entry := range entries {
+		replaced := false
+		for i := range merged {
+			if entryKey(merged[i]) == entryKey(entry) {
+				merged[i] = entry
+				replaced = true
+			}
+		}
+		if !replaced {
+			merged = append(merged, entry)
+		}
+	}
+	encoded, _ := json.Marshal(merged)
+	return string(encoded), nil
+}
+
+// fakeKustoPolicyLiteral extracts the JSON of a policy given as a string literal or a multi-line ``` literal
+func fakeKustoPolicyLiteral(args string) (string, error) {
+	args = strings.TrimSpace(args)
+	if strings.HasPrefix(args, "```") && strings.HasSuffix(args, "```") && len(args) >= 6 {
+		return strings.TrimSpace(args[3 : len(args)-3]), nil
+	}
+	if literal, ok := fakeKustoDecodeString(args); ok {
+		return literal, nil
+	}
+	return "", fmt.Errorf("expected a string literal, got: %s", args)
+}
+
+func (f *fakeKusto) printTimespan(db *fakeKustoDatabase, m []string) (*fakeKustoResult, error) {
+	value, err := parseFakeKustoTimespan(fakeKustoLiteral(m[1]))
+	if err != nil {
+		return nil, err
+	}
+	unit, err := parseFakeKustoTimespan("1" + m[2])
+	if err != nil {
+		return nil, err
+	}
+	result := newFakeKustoResult("Result:string")
+	result.addRow(strconv.FormatInt(int64(value/unit), 10))
+	return result, nil
+}
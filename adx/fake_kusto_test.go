@@ -0,0 +1,542 @@
+package adx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Azure/azure-kusto-go/kusto"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeKusto is an in-process stand-in for an ADX cluster. It speaks the v1 (mgmt) and v2 (query) REST
+// frames parsed by azure-kusto-go, records every command it receives and answers .show commands from
+// an in-memory catalog that is updated by the control commands the resources issue.
+type fakeKusto struct {
+	server *httptest.Server
+
+	mu        sync.Mutex
+	commands  []fakeKustoCommand
+	databases map[string]*fakeKustoDatabase
+	cluster   *fakeKustoCluster
+	failures  []*fakeKustoFailure
+	// results answers queries the fake cannot evaluate, keyed by their CSL
+	results map[string]*fakeKustoResult
+}
+
+// fakeKustoFailure makes the next Count commands starting with Prefix fail with Err
+type fakeKustoFailure struct {
+	Prefix string
+	Count  int
+	Err    *fakeKustoError
+}
+
+type fakeKustoCommand struct {
+	Database string
+	CSL      string
+	Mgmt     bool
+}
+
+type fakeKustoRequest struct {
+	DB  string `json:"db"`
+	CSL string `json:"csl"`
+}
+
+// fakeKustoError is returned by command handlers to answer with a Kusto REST error. Errors are
+// permanent 400 responses unless Status is set.
+type fakeKustoError struct {
+	Code    string
+	Message string
+	Status  int
+}
+
+func (e *fakeKustoError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+func fakeKustoEntityNotFound(format string, args ...interface{}) error {
+	return &fakeKustoError{Code: "BadRequest_EntityNotFound", Message: fmt.Sprintf(format, args...)}
+}
+
+type fakeKustoColumn struct {
+	Name string
+	Type string
+}
+
+type fakeKustoResult struct {
+	Columns []fakeKustoColumn
+	Rows    [][]interface{}
+}
+
+// newFakeKustoResult builds an empty result from "Name:type" column descriptors
+func newFakeKustoResult(columns ...string) *fakeKustoResult {
+	result := &fakeKustoResult{Rows: make([][]interface{}, 0)}
+	for _, c := range columns {
+		parts := strings.SplitN(c, ":", 2)
+		result.Columns = append(result.Columns, fakeKustoColumn{Name: parts[0], Type: parts[1]})
+	}
+	return result
+}
+
+func (r *fakeKustoResult) addRow(values ...interface{}) {
+	r.Rows = append(r.Rows, values)
+}
+
+type fakeKustoV1Column struct {
+	ColumnName string
+	ColumnType string
+}
+
+type fakeKustoV1Table struct {
+	TableName string
+	Columns   []fakeKustoV1Column
+	Rows      [][]interface{}
+}
+
+// v2 frames must start with FrameType, the client sniffs it before decoding the frame
+type fakeKustoV2DataSetHeader struct {
+	FrameType     string
+	IsProgressive bool
+	Version       string
+}
+
+type fakeKustoV2Column struct {
+	ColumnName string
+	ColumnType string
+}
+
+type fakeKustoV2DataTable struct {
+	FrameType string
+	TableId   int
+	TableKind string
+	TableName string
+	Columns   []fakeKustoV2Column
+	Rows      [][]interface{}
+}
+
+type fakeKustoV2DataSetCompletion struct {
+	FrameType string
+	HasErrors bool
+	Cancelled bool
+}
+
+// newFakeKusto starts a fake cluster and points every kusto client built by the provider at it
+// for the duration of the test
+func newFakeKusto(t *testing.T) *fakeKusto {
+	f := &fakeKusto{
+		databases: make(map[string]*fakeKustoDatabase),
+		cluster:   newFakeKustoCluster(),
+		results:   make(map[string]*fakeKustoResult),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/rest/mgmt", f.handle(true))
+	mux.HandleFunc("/v2/rest/query", f.handle(false))
+	f.server = httptest.NewTLSServer(mux)
+
+	previousKustoClient := newKustoClient
+	newKustoClient = func(endpoint string, _ kusto.Authorization, options ...kusto.Option) (*kusto.Client, error) {
+		options = append(options, kusto.WithHttpClient(f.server.Client()))
+		return kusto.New(endpoint, kusto.Authorization{Authorizer: autorest.NullAuthorizer{}}, options...)
+	}
+	// the provider logs every command it builds, keep test output readable
+	log.SetOutput(io.Discard)
+	t.Cleanup(func() {
+		newKustoClient = previousKustoClient
+		log.SetOutput(os.Stderr)
+		f.server.Close()
+	})
+
+	return f
+}
+
+// meta returns provider meta whose default cluster is the fake cluster
+func (f *fakeKusto) meta() *Meta {
+	return &Meta{
+		StopContext:     context.Background(),
+		KustoClientsMap: make(map[string]*kusto.Client),
+		DefaultClusterConfig: &ClusterConfig{
+			URI:          f.server.URL,
+			ClientID:     "00000000-0000-0000-0000-000000000000",
+			ClientSecret: "fake",
+			TenantID:     "00000000-0000-0000-0000-000000000000",
+		},
+	}
+}
+
+// endpoint is the cluster part of resource ids created against the fake cluster
+func (f *fakeKusto) endpoint() string {
+	return strings.TrimPrefix(f.server.URL, "https://")
+}
+
+// Commands returns the commands received so far, in order
+func (f *fakeKusto) Commands() []fakeKustoCommand {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]fakeKustoCommand(nil), f.commands...)
+}
+
+// controlCommands returns the CSL of received commands that are not .show commands
+func (f *fakeKusto) controlCommands() []string {
+	var result []string
+	for _, c := range f.Commands() {
+		if c.Mgmt && !strings.HasPrefix(c.CSL, ".show") {
+			result = append(result, c.CSL)
+		}
+	}
+	return result
+}
+
+// failNext makes the next count commands starting with prefix fail with the given status and error code,
+// the commands are not executed
+func (f *fakeKusto) failNext(prefix string, count int, status int, code string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures = append(f.failures, &fakeKustoFailure{
+		Prefix: prefix,
+		Count:  count,
+		Err:    &fakeKustoError{Code: code, Message: fmt.Sprintf("%s: injected failure", code), Status: status},
+	})
+}
+
+// answerQuery makes the fake cluster answer the query csl with result
+func (f *fakeKusto) answerQuery(csl string, result *fakeKustoResult) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.results[strings.TrimSpace(csl)] = result
+}
+
+// injectedFailure returns the error of a pending failure matching csl, if any
+func (f *fakeKusto) injectedFailure(csl string) error {
+	for _, failure := range f.failures {
+		if failure.Count > 0 && strings.HasPrefix(csl, failure.Prefix) {
+			failure.Count--
+			return failure.Err
+		}
+	}
+	return nil
+}
+
+// database returns the named database, creating it on first use as the fake cluster hosts any database
+func (f *fakeKusto) database(name string) *fakeKustoDatabase {
+	name = fakeKustoUnquote(name)
+	db, ok := f.databases[name]
+	if !ok {
+		db = newFakeKustoDatabase(name)
+		f.databases[name] = db
+	}
+	return db
+}
+
+func (f *fakeKusto) handle(mgmt bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req fakeKustoRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		csl := strings.TrimSpace(req.CSL)
+
+		f.mu.Lock()
+		f.commands = append(f.commands, fakeKustoCommand{Database: req.DB, CSL: csl, Mgmt: mgmt})
+		var result *fakeKustoResult
+		err := f.injectedFailure(csl)
+		switch {
+		case err != nil:
+			// injected failures do not reach the catalog
+		case mgmt:
+			result, err = f.executeMgmt(f.database(req.DB), csl)
+		default:
+			result, err = f.executeQuery(f.database(req.DB), csl)
+		}
+		f.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if err != nil {
+			code, status := "BadRequest_SyntaxError", http.StatusBadRequest
+			if kustoErr, ok := err.(*fakeKustoError); ok {
+				code = kustoErr.Code
+				if kustoErr.Status != 0 {
+					status = kustoErr.Status
+				}
+			}
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"error": map[string]interface{}{
+					"code":       code,
+					"message":    err.Error(),
+					"@permanent": status == http.StatusBadRequest,
+				},
+			})
+			return
+		}
+
+		if mgmt {
+			json.NewEncoder(w).Encode(result.v1())
+		} else {
+			json.NewEncoder(w).Encode(result.v2())
+		}
+	}
+}
+
+func (r *fakeKustoResult) v1() map[string]interface{} {
+	table := fakeKustoV1Table{TableName: "Table_0", Rows: r.Rows}
+	for _, c := range r.Columns {
+		table.Columns = append(table.Columns, fakeKustoV1Column{ColumnName: c.Name, ColumnType: c.Type})
+	}
+	return map[string]interface{}{"Tables": []fakeKustoV1Table{table}}
+}
+
+func (r *fakeKustoResult) v2() []interface{} {
+	table := fakeKustoV2DataTable{FrameType: "DataTable", TableKind: "PrimaryResult", TableName: "PrimaryResult", Rows: r.Rows}
+	for _, c := range r.Columns {
+		table.Columns = append(table.Columns, fakeKustoV2Column{ColumnName: c.Name, ColumnType: c.Type})
+	}
+	return []interface{}{
+		fakeKustoV2DataSetHeader{FrameType: "DataSetHeader", Version: "v2.0"},
+		table,
+		fakeKustoV2DataSetCompletion{FrameType: "DataSetCompletion"},
+	}
+}
+
+// fakeKustoUnquote strips identifier quoting such as ['name'] or ["name"]
+func fakeKustoUnquote(name string) string {
+	name = strings.TrimSpace(name)
+	if len(name) >= 4 && name[0] == '[' && name[len(name)-1] == ']' {
+		if inner, ok := fakeKustoDecodeString(name[1 : len(name)-1]); ok {
+			return inner
+		}
+	}
+	return name
+}
+
+// fakeKustoDecodeString decodes a single '...' or "..." string literal with backslash escapes, or a
+// verbatim @'...' literal. ok is false when s is not exactly one string literal.
+func fakeKustoDecodeString(s string) (string, bool) {
+	s = strings.TrimSpace(s)
+	verbatim := strings.HasPrefix(s, "@")
+	if verbatim {
+		s = s[1:]
+	}
+	if len(s) < 2 || (s[0] != '\'' && s[0] != '"') || s[len(s)-1] != s[0] {
+		return "", false
+	}
+	quote := s[0]
+	inner := s[1 : len(s)-1]
+	if verbatim {
+		return strings.ReplaceAll(inner, string([]byte{quote, quote}), string(quote)), true
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(inner); i++ {
+		c := inner[i]
+		switch {
+		case c == quote:
+			return "", false
+		case c == '\\' && i+1 < len(inner):
+			i++
+			switch inner[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			default:
+				sb.WriteByte(inner[i])
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String(), true
+}
+
+// fakeResource drives a resource through the same Diff/Apply/Refresh/Import calls terraform makes,
+// against the fake cluster
+type fakeResource struct {
+	t        *testing.T
+	resource *schema.Resource
+	meta     *Meta
+	state    *terraform.InstanceState
+
+	destroyed *terraform.InstanceState
+}
+
+func newFakeResource(t *testing.T, f *fakeKusto, resource *schema.Resource) *fakeResource {
+	return &fakeResource{t: t, resource: resource, meta: f.meta()}
+}
+
+// apply creates or updates the resource from the given configuration, then refreshes it and checks
+// that the configuration does not produce a further diff
+func (r *fakeResource) apply(config map[string]interface{}) *terraform.InstanceState {
+	ctx := context.Background()
+	resourceConfig := terraform.NewResourceConfigRaw(config)
+
+	diags := r.resource.Validate(resourceConfig)
+	if diags.HasError() {
+		r.t.Fatalf("validate: %+v", diags)
+	}
+
+	diff, err := r.resource.Diff(ctx, r.state, resourceConfig, r.meta)
+	if err != nil {
+		r.t.Fatalf("diff: %+v", err)
+	}
+	if diff == nil {
+		r.t.Fatalf("expected a diff to apply")
+	}
+
+	state, diags := r.resource.Apply(ctx, r.state, diff, r.meta)
+	if diags.HasError() {
+		r.t.Fatalf("apply: %+v", diags)
+	}
+	if state == nil || state.ID == "" {
+		r.t.Fatalf("apply did not set an id")
+	}
+	r.state = state
+
+	r.refresh()
+	if r.state == nil {
+		r.t.Fatalf("resource disappeared after apply")
+	}
+
+	diff, err = r.resource.Diff(ctx, r.state, resourceConfig, r.meta)
+	if err != nil {
+		r.t.Fatalf("diff after apply: %+v", err)
+	}
+	if diff != nil {
+		for k, v := range diff.Attributes {
+			// blocks that are optional and computed but never read stay unknown outside of terraform core,
+			// which stores them as empty
+			if v.NewComputed && v.Old == "" {
+				continue
+			}
+			r.t.Errorf("configuration should not produce a diff after apply: %s %q => %q", k, v.Old, v.New)
+		}
+	}
+	return r.state
+}
+
+// read reads a data source with the given configuration, returning the diagnostics when it fails
+func (r *fakeResource) read(config map[string]interface{}) (*terraform.InstanceState, diag.Diagnostics) {
+	ctx := context.Background()
+	resourceConfig := terraform.NewResourceConfigRaw(config)
+
+	diags := r.resource.Validate(resourceConfig)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	diff, err := r.resource.Diff(ctx, nil, resourceConfig, r.meta)
+	if err != nil {
+		r.t.Fatalf("diff: %+v", err)
+	}
+
+	state, diags := r.resource.ReadDataApply(ctx, diff, r.meta)
+	r.state = state
+	return state, diags
+}
+
+// refresh reads the resource, leaving a nil state when it no longer exists
+func (r *fakeResource) refresh() *terraform.InstanceState {
+	if r.state == nil {
+		return nil
+	}
+	state, diags := r.resource.RefreshWithoutUpgrade(context.Background(), r.state, r.meta)
+	if diags.HasError() {
+		r.t.Fatalf("refresh: %+v", diags)
+	}
+	if state != nil && state.ID == "" {
+		state = nil
+	}
+	r.state = state
+	return state
+}
+
+// destroy deletes the resource
+func (r *fakeResource) destroy() {
+	if r.state == nil {
+		r.t.Fatalf("nothing to destroy")
+	}
+	_, diags := r.resource.Apply(context.Background(), r.state, &terraform.InstanceDiff{Destroy: true}, r.meta)
+	if diags.HasError() {
+		r.t.Fatalf("destroy: %+v", diags)
+	}
+	r.destroyed = r.state
+	r.state = nil
+}
+
+// checkDestroyed reads the destroyed resource back and checks that it no longer exists
+func (r *fakeResource) checkDestroyed() {
+	if r.destroyed == nil {
+		r.t.Fatalf("nothing was destroyed")
+	}
+	state, diags := r.resource.RefreshWithoutUpgrade(context.Background(), r.destroyed, r.meta)
+	if diags.HasError() {
+		r.t.Fatalf("refresh after destroy: %+v", diags)
+	}
+	if state != nil && state.ID != "" {
+		r.t.Errorf("resource %s still exists after destroy", state.ID)
+	}
+}
+
+// importState imports the resource by id and reads it, as `terraform import` does
+func (r *fakeResource) importState(id string) *terraform.InstanceState {
+	ctx := context.Background()
+	if r.resource.Importer == nil {
+		r.t.Fatalf("resource does not support import")
+	}
+
+	d := r.resource.Data(nil)
+	d.SetId(id)
+	var imported []*schema.ResourceData
+	var err error
+	if r.resource.Importer.StateContext != nil {
+		imported, err = r.resource.Importer.StateContext(ctx, d, r.meta)
+	} else {
+		imported, err = r.resource.Importer.State(d, r.meta)
+	}
+	if err != nil {
+		r.t.Fatalf("import: %+v", err)
+	}
+	if len(imported) != 1 {
+		r.t.Fatalf("import returned %d resources, expected 1", len(imported))
+	}
+
+	r.state = imported[0].State()
+	return r.refresh()
+}
+
+// checkImport imports the current resource into a fresh instance and compares the attributes read
+// back, ignoring attributes that are only known from configuration
+func (r *fakeResource) checkImport(f *fakeKusto, ignore ...string) {
+	if r.state == nil {
+		r.t.Fatalf("nothing to import")
+	}
+	imported := newFakeResource(r.t, f, r.resource).importState(r.state.ID)
+	if imported == nil {
+		r.t.Fatalf("imported resource was not found")
+	}
+
+	ignored := map[string]bool{}
+	for _, k := range ignore {
+		ignored[k] = true
+	}
+	for k, v := range r.state.Attributes {
+		if ignored[strings.SplitN(k, ".", 2)[0]] || strings.HasPrefix(k, "cluster") {
+			continue
+		}
+		assert.Equal(r.t, v, imported.Attributes[k], "imported attribute %s", k)
+	}
+}
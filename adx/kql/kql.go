@@ -0,0 +1,375 @@
+// Package kql builds Kusto control commands. Values are emitted as escaped literals and entity names as
+// identifiers, so that user input cannot change the structure of a command.
+package kql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// StringLiteral returns value as a single-quoted KQL string literal
+func StringLiteral(value string) string {
+	var sb strings.Builder
+	sb.WriteByte('\'')
+	for _, r := range value {
+		switch r {
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\'':
+			sb.WriteString(`\'`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('\'')
+	return sb.String()
+}
+
+var plainIdentifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// reservedKeywords are names that are part of the query or control command syntax, an entity with one of
+// these names must be quoted. Keywords are case sensitive, so Table or Count can be used as is.
+var reservedKeywords = map[string]bool{
+	"access": true, "alias": true, "and": true, "anomalychart": true, "areachart": true, "as": true,
+	"asc": true, "barchart": true, "between": true, "bool": true, "boolean": true, "by": true,
+	"card": true, "cluster": true, "columnchart": true, "column": true, "consume": true, "contains": true,
+	"count": true, "database": true, "datascope": true, "datatable": true, "date": true, "datetime": true,
+	"decimal": true, "default": true, "desc": true, "distinct": true, "double": true, "dynamic": true,
+	"evaluate": true, "extend": true, "external_table": true, "facet": true, "false": true, "filter": true,
+	"find": true, "float": true, "fork": true, "from": true, "function": true, "getschema": true,
+	"guid": true, "has": true, "in": true, "int": true, "int64": true, "invoke": true, "join": true,
+	"kind": true, "let": true, "limit": true, "linechart": true, "long": true, "lookup": true,
+	"materialize": true, "materialized_view": true, "not": true,
+	"null": true, "of": true, "on": true, "or": true, "order": true, "parse": true, "partition": true,
+	"pattern": true, "piechart": true, "pivotchart": true, "policy": true, "print": true, "project": true,
+	"range": true, "real": true, "reduce": true, "render": true, "restrict": true, "sample": true,
+	"scan": true, "scatterchart": true, "search": true, "serialize": true, "set": true, "sort": true,
+	"stacked": true, "stackedareachart": true, "string": true, "summarize": true, "table": true,
+	"take": true, "time": true, "timechart": true, "timespan": true, "to": true, "top": true,
+	"toscalar": true, "true": true, "typeof": true, "union": true, "uniqueidentifier": true,
+	"where": true, "with": true,
+}
+
+// RequiresQuoting reports whether name has to be written in the ['name'] form: names may only contain
+// ASCII letters, digits and underscores, may not start with a digit and may not be a keyword
+func RequiresQuoting(name string) bool {
+	return !plainIdentifierPattern.MatchString(name) || reservedKeywords[name]
+}
+
+// Identifier returns an entity name, bracket-quoted when it cannot be used as is
+func Identifier(name string) string {
+	if IsQuotedIdentifier(name) || !RequiresQuoting(name) {
+		return name
+	}
+	return QuoteIdentifier(name)
+}
+
+// QuoteIdentifier always returns the entity name in the ['name'] form
+func QuoteIdentifier(name string) string {
+	return "[" + StringLiteral(name) + "]"
+}
+
+// IsQuotedIdentifier reports whether name is already in the ['name'] or ["name"] form, with a single
+// well-formed string literal between the brackets. A name such as ['a'] | print 1 ['b'] is not quoted.
+func IsQuotedIdentifier(name string) bool {
+	if len(name) < 4 || name[0] != '[' || name[len(name)-1] != ']' {
+		return false
+	}
+	quote := name[1]
+	if (quote != '\'' && quote != '"') || name[len(name)-2] != quote {
+		return false
+	}
+	inner := name[2 : len(name)-2]
+	for i := 0; i < len(inner); i++ {
+		switch inner[i] {
+		case '\\':
+			// an escape must not consume the closing quote
+			if i++; i == len(inner) {
+				return false
+			}
+		case quote, '\n', '\r':
+			return false
+		}
+	}
+	return true
+}
+
+// UnquoteIdentifier returns the entity name of a ['name'] or ["name"] identifier, other names are
+// returned unchanged
+func UnquoteIdentifier(name string) string {
+	if !IsQuotedIdentifier(name) {
+		return name
+	}
+	inner := name[2 : len(name)-2]
+	var sb strings.Builder
+	for i := 0; i < len(inner); i++ {
+		if inner[i] == '\\' && i+1 < len(inner) {
+			i++
+			switch inner[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			default:
+				sb.WriteByte(inner[i])
+			}
+			continue
+		}
+		sb.WriteByte(inner[i])
+	}
+	return sb.String()
+}
+
+// SplitList splits s on commas that are not part of a string literal or quoted identifier, e.g. the
+// columns of a table schema. The parts are not trimmed.
+func SplitList(s string) []string {
+	var parts []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == ',':
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+var dateTimePattern = regexp.MustCompile(`^[0-9A-Za-z:.\-+ ]+$`)
+
+// DateTime returns a datetime literal for a value such as 2024-01-01T00:00:00Z, a value that already
+// is a datetime(...) literal is accepted as well
+func DateTime(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(strings.ToLower(value), "datetime(") && strings.HasSuffix(value, ")") {
+		value = strings.TrimSpace(value[len("datetime(") : len(value)-1])
+	}
+	if !dateTimePattern.MatchString(value) {
+		return "", fmt.Errorf("invalid datetime value %q", value)
+	}
+	return "datetime(" + value + ")", nil
+}
+
+var timespanPattern = regexp.MustCompile(`(?i)^(?:\d+(?:\.\d+)?\s*(?:d|h|m|s|ms|tick|ticks|microsecond|microseconds|millisecond|milliseconds|second|seconds|minute|minutes|hour|hours|day|days)|(?:\d+\.)?\d{1,2}:\d{2}(?::\d{2}(?:\.\d+)?)?|(?:time|timespan)\(\s*[\w.:\s]*\))$`)
+
+// Timespan checks that value is a timespan literal such as 30d, 1.12:00:00 or time(2h) and returns it
+func Timespan(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if !timespanPattern.MatchString(value) {
+		return "", fmt.Errorf("invalid timespan value %q", value)
+	}
+	return value, nil
+}
+
+var guidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// ScalarTypes are the scalar types ScalarLiteral accepts
+var ScalarTypes = []string{"string", "bool", "int", "long", "real", "decimal", "datetime", "timespan", "guid", "dynamic"}
+
+// ScalarLiteral returns value as a literal of the given scalar type, e.g. long(42) or 'text', checking that
+// the value is valid for the type
+func ScalarLiteral(scalarType string, value string) (string, error) {
+	// strings are kept as given, surrounding whitespace is only dropped from the other types
+	if scalarType == "string" {
+		return StringLiteral(value), nil
+	}
+	value = strings.TrimSpace(value)
+	var err error
+	switch scalarType {
+	case "bool":
+		_, err = strconv.ParseBool(value)
+	case "int":
+		_, err = strconv.ParseInt(value, 10, 32)
+	case "long":
+		_, err = strconv.ParseInt(value, 10, 64)
+	case "real", "decimal":
+		_, err = strconv.ParseFloat(value, 64)
+	case "datetime":
+		return DateTime(value)
+	case "timespan":
+		if value, err = Timespan(value); err == nil {
+			return "timespan(" + value + ")", nil
+		}
+		return "", err
+	case "guid":
+		if !guidPattern.MatchString(value) {
+			err = fmt.Errorf("not a guid")
+		}
+	case "dynamic":
+		if !json.Valid([]byte(value)) {
+			err = fmt.Errorf("not valid JSON")
+		}
+	default:
+		return "", fmt.Errorf("unsupported scalar type %q", scalarType)
+	}
+	if err != nil {
+		return "", fmt.Errorf("invalid %s value %q: %+v", scalarType, value, err)
+	}
+	return scalarType + "(" + value + ")", nil
+}
+
+// QualifiedIdentifier returns a dotted reference such as Table.Column
+func QualifiedIdentifier(names ...string) string {
+	identifiers := make([]string, len(names))
+	for i, name := range names {
+		identifiers[i] = Identifier(name)
+	}
+	return strings.Join(identifiers, ".")
+}
+
+// JSON serializes value with encoding/json and returns it as a string literal, as policy commands expect
+func JSON(value interface{}) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+	return StringLiteral(strings.TrimSuffix(buf.String(), "\n")), nil
+}
+
+// Builder assembles a command from keywords, identifiers and literals separated by spaces
+type Builder struct {
+	parts []string
+}
+
+// New starts a command with the given keywords, e.g. New(".alter", "table")
+func New(keywords ...string) *Builder {
+	return (&Builder{}).Keyword(keywords...)
+}
+
+// Keyword appends command keywords verbatim, they must never come from user input
+func (b *Builder) Keyword(keywords ...string) *Builder {
+	for _, keyword := range keywords {
+		if keyword != "" {
+			b.parts = append(b.parts, keyword)
+		}
+	}
+	return b
+}
+
+// Identifier appends an entity name
+func (b *Builder) Identifier(name string) *Builder {
+	b.parts = append(b.parts, Identifier(name))
+	return b
+}
+
+// QualifiedIdentifier appends a dotted reference such as Table.Column
+func (b *Builder) QualifiedIdentifier(names ...string) *Builder {
+	b.parts = append(b.parts, QualifiedIdentifier(names...))
+	return b
+}
+
+// IdentifierList appends a parenthesized list of entity names, e.g. (T1, T2)
+func (b *Builder) IdentifierList(names ...string) *Builder {
+	identifiers := make([]string, len(names))
+	for i, name := range names {
+		identifiers[i] = Identifier(name)
+	}
+	b.parts = append(b.parts, "("+strings.Join(identifiers, ", ")+")")
+	return b
+}
+
+// StringLiteral appends value as a string literal
+func (b *Builder) StringLiteral(value string) *Builder {
+	b.parts = append(b.parts, StringLiteral(value))
+	return b
+}
+
+// StringList appends a parenthesized list of string literals, e.g. ('aaduser=...', 'aadgroup=...')
+func (b *Builder) StringList(values ...string) *Builder {
+	literals := make([]string, len(values))
+	for i, value := range values {
+		literals[i] = StringLiteral(value)
+	}
+	b.parts = append(b.parts, "("+strings.Join(literals, ", ")+")")
+	return b
+}
+
+// Literal appends a value that already is a KQL literal, such as a timespan or the output of JSON
+func (b *Builder) Literal(literal string) *Builder {
+	b.parts = append(b.parts, literal)
+	return b
+}
+
+// With appends a with(...) clause, nothing is appended when there are no properties
+func (b *Builder) With(properties *Properties) *Builder {
+	if properties != nil && len(properties.entries) > 0 {
+		b.parts = append(b.parts, properties.Clause())
+	}
+	return b
+}
+
+// Query appends KQL query text, such as a function body or the query after <|
+func (b *Builder) Query(query string) *Builder {
+	b.parts = append(b.parts, query)
+	return b
+}
+
+func (b *Builder) String() string {
+	return strings.Join(b.parts, " ")
+}
+
+// Properties is the property list of a with(...) clause
+type Properties struct {
+	entries []string
+}
+
+func NewProperties() *Properties {
+	return &Properties{}
+}
+
+// String adds a string property, e.g. docstring='...'
+func (p *Properties) String(name string, value string) *Properties {
+	return p.Literal(name, StringLiteral(value))
+}
+
+// Bool adds a boolean property, e.g. backfill=true
+func (p *Properties) Bool(name string, value bool) *Properties {
+	return p.Literal(name, strconv.FormatBool(value))
+}
+
+// Int adds an integer property, e.g. sizeLimit=100
+func (p *Properties) Int(name string, value int) *Properties {
+	return p.Literal(name, strconv.Itoa(value))
+}
+
+// Literal adds a property whose value already is a KQL literal, such as a timespan or datetime
+func (p *Properties) Literal(name string, literal string) *Properties {
+	p.entries = append(p.entries, name+"="+literal)
+	return p
+}
+
+func (p *Properties) Len() int {
+	return len(p.entries)
+}
+
+// Clause returns the with(...) clause, or an empty string when there are no properties
+func (p *Properties) Clause() string {
+	if len(p.entries) == 0 {
+		return ""
+	}
+	return "with(" + strings.Join(p.entries, ", ") + ")"
+}
@@ -0,0 +1,136 @@
+package kql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStringLiteral(t *testing.T) {
+	cases := map[string]string{
+		"plain":          `'plain'`,
+		"it's":           `'it\'s'`,
+		`say "hi"`:       `'say "hi"'`,
+		`c:\temp`:        `'c:\\temp'`,
+		"line\nbreak\t!": `'line\nbreak\t!'`,
+		"":               `''`,
+	}
+	for input, expected := range cases {
+		assert.Equal(t, expected, StringLiteral(input), input)
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	assert.Equal(t, "MyTable", Identifier("MyTable"))
+	assert.Equal(t, "['my-table']", Identifier("my-table"))
+	assert.Equal(t, "['my-table']", Identifier("['my-table']"))
+	assert.Equal(t, `["my-table"]`, Identifier(`["my-table"]`))
+	assert.Equal(t, `['it\'s-table']`, Identifier("it's-table"))
+	assert.Equal(t, "['my-table'].Column", QualifiedIdentifier("my-table", "Column"))
+
+	for _, name := range []string{"my table", "my.table", "1table", "tâble", "table", "database", "policy", "where", ""} {
+		assert.True(t, RequiresQuoting(name), name)
+	}
+	for _, name := range []string{"MyTable", "_table", "table_1", "T", "Table", "Count"} {
+		assert.False(t, RequiresQuoting(name), name)
+	}
+	assert.Equal(t, "['my table']", Identifier("my table"))
+	assert.Equal(t, "['table']", Identifier("table"))
+	assert.Equal(t, "['1table']", Identifier("1table"))
+
+	// a name that only looks quoted is escaped as a whole
+	injected := "['a'] <| print 1 ; .drop table ['b']"
+	assert.False(t, IsQuotedIdentifier(injected))
+	assert.Equal(t, `['[\'a\'] <| print 1 ; .drop table [\'b\']']`, Identifier(injected))
+	assert.Equal(t, injected, UnquoteIdentifier(Identifier(injected)))
+	assert.False(t, IsQuotedIdentifier(`['a\']`))
+	assert.True(t, IsQuotedIdentifier(`['it\'s']`))
+}
+
+func TestUnquoteIdentifier(t *testing.T) {
+	assert.Equal(t, "name", UnquoteIdentifier("name"))
+	assert.Equal(t, "my table", UnquoteIdentifier("['my table']"))
+	assert.Equal(t, "my table", UnquoteIdentifier(`["my table"]`))
+	assert.Equal(t, "it's", UnquoteIdentifier(QuoteIdentifier("it's")))
+	assert.Equal(t, `c:\temp`, UnquoteIdentifier(QuoteIdentifier(`c:\temp`)))
+}
+
+func TestSplitList(t *testing.T) {
+	assert.Equal(t, []string{"a:string", " ['b, c']:int", "d:long"}, SplitList("a:string, ['b, c']:int,d:long"))
+	assert.Equal(t, []string{`['it\'s,']:string`, "x:int"}, SplitList(`['it\'s,']:string,x:int`))
+}
+
+func TestDateTime(t *testing.T) {
+	value, err := DateTime("2024-01-01T00:00:00Z")
+	assert.NoError(t, err)
+	assert.Equal(t, "datetime(2024-01-01T00:00:00Z)", value)
+
+	value, err = DateTime("datetime(2024-01-01)")
+	assert.NoError(t, err)
+	assert.Equal(t, "datetime(2024-01-01)", value)
+
+	_, err = DateTime("2024-01-01) | print 1 //")
+	assert.ErrorContains(t, err, "invalid datetime value")
+}
+
+func TestTimespan(t *testing.T) {
+	for _, value := range []string{"30d", "1.5h", "12:00:00", "1.12:00:00", "time(2h)", "100ms"} {
+		result, err := Timespan(value)
+		assert.NoError(t, err, value)
+		assert.Equal(t, value, result)
+	}
+
+	_, err := Timespan("3d recoverability = disabled")
+	assert.ErrorContains(t, err, "invalid timespan value")
+}
+
+func TestScalarLiteral(t *testing.T) {
+	for _, c := range []struct{ scalarType, value, literal string }{
+		{"string", "it's", `'it\'s'`},
+		{"string", "  padded ", `'  padded '`},
+		{"long", " 42 ", "long(42)"},
+		{"bool", "true", "bool(true)"},
+		{"long", "42", "long(42)"},
+		{"real", "0.5", "real(0.5)"},
+		{"datetime", "2024-01-01T00:00:00Z", "datetime(2024-01-01T00:00:00Z)"},
+		{"timespan", "1d", "timespan(1d)"},
+		{"guid", "00000000-0000-0000-0000-000000000001", "guid(00000000-0000-0000-0000-000000000001)"},
+		{"dynamic", `["a", "b"]`, `dynamic(["a", "b"])`},
+	} {
+		literal, err := ScalarLiteral(c.scalarType, c.value)
+		assert.NoError(t, err, c.scalarType)
+		assert.Equal(t, c.literal, literal)
+	}
+
+	_, err := ScalarLiteral("long", "1) | take 1 //")
+	assert.ErrorContains(t, err, "invalid long value")
+
+	_, err = ScalarLiteral("dynamic", "{) | print 1 //")
+	assert.ErrorContains(t, err, "invalid dynamic value")
+
+	_, err = ScalarLiteral("table", "T")
+	assert.ErrorContains(t, err, "unsupported scalar type")
+}
+
+func TestJSON(t *testing.T) {
+	value, err := JSON([]map[string]interface{}{{"Query": `T | where A == "it's" and B has "<x>"`}})
+	assert.NoError(t, err)
+	assert.Equal(t, `'[{"Query":"T | where A == \\"it\'s\\" and B has \\"<x>\\""}]'`, value)
+}
+
+func TestBuilder(t *testing.T) {
+	properties := NewProperties().String("docstring", "it's a table").Bool("backfill", true).Int("lookback", 3)
+	assert.Equal(t, 3, properties.Len())
+
+	command := New(".create", "table").Identifier("my-table").Literal("(a:string)").With(properties).String()
+	assert.Equal(t, `.create table ['my-table'] (a:string) with(docstring='it\'s a table', backfill=true, lookback=3)`, command)
+
+	command = New(".create", "table").Identifier("T").Literal("(a:string)").With(NewProperties()).String()
+	assert.Equal(t, ".create table T (a:string)", command)
+
+	command = New(".alter", "tables").IdentifierList("T1", "T-2").Keyword("policy", "ingestionbatching").String()
+	assert.Equal(t, ".alter tables (T1, ['T-2']) policy ingestionbatching", command)
+
+	command = New(".add", "table").Identifier("T").Keyword("admins").StringList("aaduser=a@b.com", "aadgroup=g").StringLiteral("note").String()
+	assert.Equal(t, ".add table T admins ('aaduser=a@b.com', 'aadgroup=g') 'note'", command)
+}
@@ -2,10 +2,12 @@ package adx
 
 import (
 	"context"
+	"time"
 
 	"github.com/favoretti/terraform-provider-adx/adx/validate"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const TerraformProviderUserAgent = "terraform-provider-adx"
@@ -28,6 +30,28 @@ func Provider() *schema.Provider {
 				ValidateDiagFunc: validate.StringIsNotEmpty,
 			},
 
+			"client_certificate_path": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      schema.MultiEnvDefaultFunc([]string{"ADX_CLIENT_CERTIFICATE_PATH"}, nil),
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+			},
+
+			"client_certificate": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				DefaultFunc:      schema.MultiEnvDefaultFunc([]string{"ADX_CLIENT_CERTIFICATE"}, nil),
+				ValidateDiagFunc: validate.StringIsBase64,
+			},
+
+			"client_certificate_password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"ADX_CLIENT_CERTIFICATE_PASSWORD"}, nil),
+			},
+
 			"adx_endpoint": {
 				Type:             schema.TypeString,
 				Optional:         true,
@@ -43,20 +67,105 @@ func Provider() *schema.Provider {
 				ValidateDiagFunc: validate.StringIsNotEmpty,
 			},
 
+			"use_msi": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"ADX_USE_MSI"}, false),
+			},
+
+			"msi_client_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      schema.MultiEnvDefaultFunc([]string{"ADX_MSI_CLIENT_ID"}, nil),
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+			},
+
+			"use_oidc": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"ADX_USE_OIDC", "ARM_USE_OIDC"}, false),
+			},
+
+			"oidc_token": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				DefaultFunc:      schema.MultiEnvDefaultFunc([]string{"ADX_OIDC_TOKEN", "ARM_OIDC_TOKEN"}, nil),
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+			},
+
+			"oidc_token_file_path": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      schema.MultiEnvDefaultFunc([]string{"ADX_OIDC_TOKEN_FILE_PATH", "ARM_OIDC_TOKEN_FILE_PATH", "AZURE_FEDERATED_TOKEN_FILE"}, nil),
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+			},
+
+			"use_cli": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"ADX_USE_CLI"}, false),
+			},
+
 			"lazy_init": {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  false,
 			},
+
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultADXMaxRetries,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"retry_max_delay": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          defaultADXRetryMaxDelay,
+				ValidateDiagFunc: validate.StringIsDuration,
+			},
+
+			"max_concurrent_commands": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 		},
 
-		DataSourcesMap: map[string]*schema.Resource{},
+		DataSourcesMap: map[string]*schema.Resource{
+			"adx_database_schema": dataSourceADXDatabaseSchema(),
+			"adx_function":        dataSourceADXFunction(),
+			"adx_functions":       dataSourceADXFunctions(),
+			"adx_principals":      dataSourceADXPrincipals(),
+			"adx_query":           dataSourceADXQuery(),
+			"adx_table":           dataSourceADXTable(),
+			"adx_tables":          dataSourceADXTables(),
+		},
 
 		ResourcesMap: map[string]*schema.Resource{
+			"adx_cluster_callout_policy":                resourceADXClusterCalloutPolicy(),
+			"adx_cluster_capacity_policy":               resourceADXClusterCapacityPolicy(),
+			"adx_cluster_principal":                     resourceADXClusterPrincipal(),
 			"adx_cluster_request_classification_policy": resourceADXClusterRequestClassificationPolicy(),
+			"adx_cluster_sandbox_policy":                resourceADXClusterSandboxPolicy(),
 
 			"adx_column_encoding_policy": resourceADXColumnEncodingPolicy(),
 
+			"adx_database_caching_policy":             resourceADXDatabaseCachingPolicy(),
+			"adx_database_ingestion_batching_policy":  resourceADXDatabaseIngestionBatchingPolicy(),
+			"adx_database_principal":                  resourceADXDatabasePrincipal(),
+			"adx_database_retention_policy":           resourceADXDatabaseRetentionPolicy(),
+			"adx_database_streaming_ingestion_policy": resourceADXDatabaseStreamingIngestionPolicy(),
+
+			"adx_entity_security_role": resourceADXEntitySecurityRole(),
+
+			"adx_security_role_principals": resourceADXSecurityRolePrincipals(),
+
+			"adx_extent_tags_retention_policy": resourceADXExtentTagsRetentionPolicy(),
+
 			"adx_external_table": resourceADXExternalTable(),
 
 			"adx_function": resourceADXFunction(),
@@ -66,21 +175,26 @@ func Provider() *schema.Provider {
 			"adx_materialized_view_retention_policy":          resourceADXMaterializedViewRetentionPolicy(),
 			"adx_materialized_view_row_level_security_policy": resourceADXMaterializedViewRowLevelSecurityPolicy(),
 
-			"adx_merge_policy":                             resourceADXMergePolicy(),
-
-			"adx_table":       		                  	resourceADXTable(),
-			"adx_table_caching_policy":		          	resourceADXTableCachingPolicy(),
-			"adx_table_restricted_view_access_policy":	resourceADXTableRestrictedViewPolicy(),
-			"adx_table_continuous_export":          	resourceADXTableContinuousExport(),
-			"adx_table_ingestion_batching_policy":  	resourceADXTableIngestionBatchingPolicy(),
-			"adx_table_ingestion_time_policy":      	resourceADXTableIngestionTimePolicy(),
-			"adx_table_mapping":                    	resourceADXTableMapping(),
-			"adx_table_partitioning_policy":        	resourceADXTablePartitioningPolicy(),
-			"adx_table_retention_policy":           	resourceADXTableRetentionPolicy(),
-			"adx_table_row_level_security_policy":  	resourceADXTableRowLevelSecurityPolicy(),
-			"adx_table_security_role":              	resourceADXTableSecurityRole(),
-			"adx_table_streaming_ingestion_policy": 	resourceADXTableStreamingIngestionPolicy(),
-			"adx_table_update_policy":              	resourceADXTableUpdatePolicy(),
+			"adx_merge_policy": resourceADXMergePolicy(),
+
+			"adx_policy": resourceADXPolicy(),
+
+			"adx_table":                               resourceADXTable(),
+			"adx_table_auto_delete_policy":            resourceADXTableAutoDeletePolicy(),
+			"adx_table_caching_policy":                resourceADXTableCachingPolicy(),
+			"adx_table_restricted_view_access_policy": resourceADXTableRestrictedViewPolicy(),
+			"adx_table_continuous_export":             resourceADXTableContinuousExport(),
+			"adx_table_ingestion_batching_policy":     resourceADXTableIngestionBatchingPolicy(),
+			"adx_table_ingestion_time_policy":         resourceADXTableIngestionTimePolicy(),
+			"adx_table_mapping":                       resourceADXTableMapping(),
+			"adx_table_partitioning_policy":           resourceADXTablePartitioningPolicy(),
+			"adx_table_retention_policy":              resourceADXTableRetentionPolicy(),
+			"adx_table_row_order_policy":              resourceADXTableRowOrderPolicy(),
+			"adx_table_row_level_security_policy":     resourceADXTableRowLevelSecurityPolicy(),
+			"adx_table_security_role":                 resourceADXTableSecurityRole(),
+			"adx_table_sharding_policy":               resourceADXTableShardingPolicy(),
+			"adx_table_streaming_ingestion_policy":    resourceADXTableStreamingIngestionPolicy(),
+			"adx_table_update_policy":                 resourceADXTableUpdatePolicy(),
 
 			"adx_workload_group": resourceADXWorkloadGroup(),
 		},
@@ -94,13 +208,29 @@ func Provider() *schema.Provider {
 func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		config := &Config{
-			ClientID:     d.Get("client_id").(string),
-			ClientSecret: d.Get("client_secret").(string),
-			TenantID:     d.Get("tenant_id").(string),
-			Endpoint:     d.Get("adx_endpoint").(string),
-			LazyInit:     d.Get("lazy_init").(bool),
+			ClientID:          d.Get("client_id").(string),
+			ClientSecret:      d.Get("client_secret").(string),
+			TenantID:          d.Get("tenant_id").(string),
+			Endpoint:          d.Get("adx_endpoint").(string),
+			LazyInit:          d.Get("lazy_init").(bool),
+			UseMSI:            d.Get("use_msi").(bool),
+			MSIClientID:       d.Get("msi_client_id").(string),
+			UseOIDC:           d.Get("use_oidc").(bool),
+			OIDCToken:         d.Get("oidc_token").(string),
+			OIDCTokenFilePath: d.Get("oidc_token_file_path").(string),
+			UseCLI:            d.Get("use_cli").(bool),
+
+			ClientCertificatePath:     d.Get("client_certificate_path").(string),
+			ClientCertificate:         d.Get("client_certificate").(string),
+			ClientCertificatePassword: d.Get("client_certificate_password").(string),
+
+			MaxRetries:            d.Get("max_retries").(int),
+			MaxConcurrentCommands: d.Get("max_concurrent_commands").(int),
 		}
 
+		// validated by the schema
+		config.RetryMaxDelay, _ = time.ParseDuration(d.Get("retry_max_delay").(string))
+
 		ua := p.UserAgent(TerraformProviderUserAgent, p.TerraformVersion)
 
 		return config.Client(ua)
@@ -0,0 +1,184 @@
+package adx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/favoretti/terraform-provider-adx/adx/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type CalloutPolicyEntry struct {
+	CalloutType     string `json:"CalloutType"`
+	CalloutUriRegex string `json:"CalloutUriRegex"`
+	CanCall         bool   `json:"CanCall"`
+}
+
+var calloutTypes = []string{
+	"kusto",
+	"sql",
+	"mysql",
+	"postgresql",
+	"cosmosdb",
+	"webapi",
+	"sandbox_artifacts",
+	"external_data",
+	"azure_digital_twins",
+	"genevametrics",
+	"azure_openai",
+}
+
+func resourceADXClusterCalloutPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceADXClusterCalloutPolicyCreateUpdate,
+		ReadContext:   resourceADXClusterCalloutPolicyRead,
+		UpdateContext: resourceADXClusterCalloutPolicyCreateUpdate,
+		DeleteContext: resourceADXClusterCalloutPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster": getClusterConfigInputSchema(),
+			"database_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+				Description:      "Database name used as context for the management command. The policy is cluster-level.",
+			},
+			"callout": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Callout destinations managed by this resource. Entries of the policy not listed here are left untouched.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"callout_type": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validate.StringInSlice(calloutTypes),
+							Description:      "Type of the callout, e.g. sql for the sql_request plugin or webapi for http_request",
+						},
+						"callout_uri_regex": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validate.StringIsNotEmpty,
+							Description:      "Regular expression the destination URI must match",
+						},
+						"can_call": {
+							Type:        schema.TypeBool,
+							Required:    true,
+							Description: "Whether calls to matching destinations are allowed",
+						},
+					},
+				},
+			},
+		},
+		CustomizeDiff: clusterConfigCustomDiff,
+	}
+}
+
+func resourceADXClusterCalloutPolicyCreateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.IsNewResource() {
+		removed := removedClusterPolicyEntries(d, "callout", calloutEntryKeyFromState)
+		if diags := removeClusterPolicyEntries(ctx, d, meta, "callout", calloutEntryKey, removed); diags.HasError() {
+			return diags
+		}
+	}
+
+	var entries []CalloutPolicyEntry
+	for _, v := range d.Get("callout").([]interface{}) {
+		callout := v.(map[string]interface{})
+		entries = append(entries, CalloutPolicyEntry{
+			CalloutType:     callout["callout_type"].(string),
+			CalloutUriRegex: callout["callout_uri_regex"].(string),
+			CanCall:         callout["can_call"].(bool),
+		})
+	}
+
+	if diags := alterMergeClusterPolicy(ctx, d, meta, "callout", entries); diags.HasError() {
+		return diags
+	}
+
+	return resourceADXClusterCalloutPolicyRead(ctx, d, meta)
+}
+
+func resourceADXClusterCalloutPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id, entries, diags := readClusterPolicyEntries(ctx, d, meta, "callout")
+	if diags.HasError() {
+		return diags
+	}
+
+	policyEntries := map[string]map[string]interface{}{}
+	for _, entry := range entries {
+		policyEntries[calloutEntryKey(entry)] = entry
+	}
+
+	// only the managed entries are read back, all entries when the resource is imported
+	var managed []string
+	for _, v := range d.Get("callout").([]interface{}) {
+		if v != nil {
+			managed = append(managed, calloutEntryKeyFromState(v.(map[string]interface{})))
+		}
+	}
+	if len(managed) == 0 {
+		for _, entry := range entries {
+			managed = append(managed, calloutEntryKey(entry))
+		}
+	}
+
+	callouts := make([]interface{}, 0, len(managed))
+	for _, key := range managed {
+		entry, ok := policyEntries[key]
+		if !ok {
+			continue
+		}
+		canCall, _ := entry["CanCall"].(bool)
+		callouts = append(callouts, map[string]interface{}{
+			"callout_type":      strings.ToLower(fmt.Sprint(entry["CalloutType"])),
+			"callout_uri_regex": fmt.Sprint(entry["CalloutUriRegex"]),
+			"can_call":          canCall,
+		})
+	}
+
+	if len(callouts) == 0 {
+		d.SetId("")
+		return diags
+	}
+
+	d.Set("database_name", id.DatabaseName)
+	d.Set("callout", callouts)
+
+	return diags
+}
+
+func resourceADXClusterCalloutPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	removed := map[string]bool{}
+	for _, v := range d.Get("callout").([]interface{}) {
+		if v != nil {
+			removed[calloutEntryKeyFromState(v.(map[string]interface{}))] = true
+		}
+	}
+
+	if diags := removeClusterPolicyEntries(ctx, d, meta, "callout", calloutEntryKey, removed); diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// calloutEntryKey identifies a callout policy entry, ADX merges entries with the same type and URI regex
+func calloutEntryKey(entry map[string]interface{}) string {
+	return strings.ToLower(fmt.Sprint(entry["CalloutType"])) + "|" + fmt.Sprint(entry["CalloutUriRegex"])
+}
+
+func calloutEntryKeyFromState(callout map[string]interface{}) string {
+	return calloutEntryKey(map[string]interface{}{
+		"CalloutType":     callout["callout_type"],
+		"CalloutUriRegex": callout["callout_uri_regex"],
+	})
+}
@@ -0,0 +1,57 @@
+package adx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXClusterCalloutPolicy_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	f.cluster.policies["callout"] = `[{"CalloutType":"sandbox_artifacts","CalloutUriRegex":"artifacts.blob.core.windows.net/","CanCall":true}]`
+	r := newFakeResource(t, f, resourceADXClusterCalloutPolicy())
+
+	state := r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"callout": []interface{}{
+			map[string]interface{}{"callout_type": "sql", "callout_uri_regex": "sqlserver.contoso.com", "can_call": true},
+			map[string]interface{}{"callout_type": "webapi", "callout_uri_regex": "api.contoso.com/.*", "can_call": true},
+		},
+	})
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|cluster|callout", f.endpoint()), state.ID)
+	assert.Equal(t, "2", state.Attributes["callout.#"])
+
+	state = r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"callout": []interface{}{
+			map[string]interface{}{"callout_type": "sql", "callout_uri_regex": "sqlserver.contoso.com", "can_call": false},
+		},
+	})
+	assert.Equal(t, "1", state.Attributes["callout.#"])
+	assert.Equal(t, "false", state.Attributes["callout.0.can_call"])
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Equal(t, []string{
+		`.alter-merge cluster policy callout '[{"CalloutType":"sql","CalloutUriRegex":"sqlserver.contoso.com","CanCall":true},{"CalloutType":"webapi","CalloutUriRegex":"api.contoso.com/.*","CanCall":true}]'`,
+		`.alter cluster policy callout '[{"CalloutType":"sandbox_artifacts","CalloutUriRegex":"artifacts.blob.core.windows.net/","CanCall":true},{"CalloutType":"sql","CalloutUriRegex":"sqlserver.contoso.com","CanCall":true}]'`,
+		`.alter-merge cluster policy callout '[{"CalloutType":"sql","CalloutUriRegex":"sqlserver.contoso.com","CanCall":false}]'`,
+		`.alter cluster policy callout '[{"CalloutType":"sandbox_artifacts","CalloutUriRegex":"artifacts.blob.core.windows.net/","CanCall":true}]'`,
+	}, f.controlCommands())
+	// entries not managed by the resource are left untouched
+	assert.JSONEq(t, `[{"CalloutType":"sandbox_artifacts","CalloutUriRegex":"artifacts.blob.core.windows.net/","CanCall":true}]`, f.cluster.policies["callout"])
+}
+
+func TestFakeADXClusterCalloutPolicy_import(t *testing.T) {
+	f := newFakeKusto(t)
+	r := newFakeResource(t, f, resourceADXClusterCalloutPolicy())
+
+	r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"callout": []interface{}{
+			map[string]interface{}{"callout_type": "webapi", "callout_uri_regex": "api.contoso.com/.*", "can_call": true},
+		},
+	})
+	r.checkImport(f)
+}
@@ -0,0 +1,196 @@
+package adx
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/favoretti/terraform-provider-adx/adx/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type ClusterCapacityPolicy struct {
+	IngestionCapacity    *OperationsCapacity   `json:"IngestionCapacity,omitempty"`
+	ExtentsMergeCapacity *ExtentsMergeCapacity `json:"ExtentsMergeCapacity,omitempty"`
+	ExportCapacity       *OperationsCapacity   `json:"ExportCapacity,omitempty"`
+}
+
+type OperationsCapacity struct {
+	ClusterMaximumConcurrentOperations int     `json:"ClusterMaximumConcurrentOperations"`
+	CoreUtilizationCoefficient         float64 `json:"CoreUtilizationCoefficient"`
+}
+
+type ExtentsMergeCapacity struct {
+	MinimumConcurrentOperationsPerNode int `json:"MinimumConcurrentOperationsPerNode"`
+	MaximumConcurrentOperationsPerNode int `json:"MaximumConcurrentOperationsPerNode"`
+}
+
+var clusterCapacityPolicyBlocks = []string{"ingestion_capacity", "extents_merge_capacity", "export_capacity"}
+
+func resourceADXClusterCapacityPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceADXClusterCapacityPolicyCreateUpdate,
+		ReadContext:   resourceADXClusterCapacityPolicyRead,
+		UpdateContext: resourceADXClusterCapacityPolicyCreateUpdate,
+		DeleteContext: resourceADXClusterCapacityPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster": getClusterConfigInputSchema(),
+			"database_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+				Description:      "Database name used as context for the management command. The policy is cluster-level.",
+			},
+			"ingestion_capacity": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Computed:     true,
+				MaxItems:     1,
+				AtLeastOneOf: clusterCapacityPolicyBlocks,
+				Elem:         operationsCapacitySchema(),
+			},
+			"extents_merge_capacity": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Computed:     true,
+				MaxItems:     1,
+				AtLeastOneOf: clusterCapacityPolicyBlocks,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"minimum_concurrent_operations_per_node": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"maximum_concurrent_operations_per_node": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+					},
+				},
+			},
+			"export_capacity": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Computed:     true,
+				MaxItems:     1,
+				AtLeastOneOf: clusterCapacityPolicyBlocks,
+				Elem:         operationsCapacitySchema(),
+			},
+		},
+		CustomizeDiff: clusterConfigCustomDiff,
+	}
+}
+
+func operationsCapacitySchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"cluster_maximum_concurrent_operations": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"core_utilization_coefficient": {
+				Type:         schema.TypeFloat,
+				Required:     true,
+				ValidateFunc: validation.FloatBetween(0, 1),
+			},
+		},
+	}
+}
+
+func resourceADXClusterCapacityPolicyCreateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// only the configured capacities that changed are merged, the others keep their value in the cluster
+	changed := func(key string) map[string]interface{} {
+		v := d.Get(key).([]interface{})
+		if len(v) == 0 || v[0] == nil || !(d.IsNewResource() || d.HasChange(key)) {
+			return nil
+		}
+		return v[0].(map[string]interface{})
+	}
+
+	var policy ClusterCapacityPolicy
+	if ingestion := changed("ingestion_capacity"); ingestion != nil {
+		policy.IngestionCapacity = expandOperationsCapacity(ingestion)
+	}
+	if extentsMerge := changed("extents_merge_capacity"); extentsMerge != nil {
+		policy.ExtentsMergeCapacity = &ExtentsMergeCapacity{
+			MinimumConcurrentOperationsPerNode: extentsMerge["minimum_concurrent_operations_per_node"].(int),
+			MaximumConcurrentOperationsPerNode: extentsMerge["maximum_concurrent_operations_per_node"].(int),
+		}
+	}
+	if export := changed("export_capacity"); export != nil {
+		policy.ExportCapacity = expandOperationsCapacity(export)
+	}
+
+	if policy.IngestionCapacity != nil || policy.ExtentsMergeCapacity != nil || policy.ExportCapacity != nil {
+		if diags := alterMergeClusterPolicy(ctx, d, meta, "capacity", policy); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceADXClusterCapacityPolicyRead(ctx, d, meta)
+}
+
+func resourceADXClusterCapacityPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id, policyJSON, diags := readClusterPolicy(ctx, d, meta, "capacity")
+	if diags.HasError() {
+		return diags
+	}
+
+	if policyJSON == "" {
+		d.SetId("")
+		return diags
+	}
+
+	var policy ClusterCapacityPolicy
+	if err := json.Unmarshal([]byte(policyJSON), &policy); err != nil {
+		return diag.Errorf("error parsing cluster capacity policy: %+v", err)
+	}
+
+	d.Set("database_name", id.DatabaseName)
+	d.Set("ingestion_capacity", flattenOperationsCapacity(policy.IngestionCapacity))
+	d.Set("export_capacity", flattenOperationsCapacity(policy.ExportCapacity))
+	if policy.ExtentsMergeCapacity != nil {
+		d.Set("extents_merge_capacity", []interface{}{map[string]interface{}{
+			"minimum_concurrent_operations_per_node": policy.ExtentsMergeCapacity.MinimumConcurrentOperationsPerNode,
+			"maximum_concurrent_operations_per_node": policy.ExtentsMergeCapacity.MaximumConcurrentOperationsPerNode,
+		}})
+	} else {
+		d.Set("extents_merge_capacity", []interface{}{})
+	}
+
+	return diags
+}
+
+func resourceADXClusterCapacityPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// the capacity policy always exists in a cluster and cannot be deleted
+	log.Printf("[INFO] Removing cluster capacity policy %q from state, the policy is left as is in the cluster", d.Id())
+	d.SetId("")
+	return nil
+}
+
+func expandOperationsCapacity(capacity map[string]interface{}) *OperationsCapacity {
+	return &OperationsCapacity{
+		ClusterMaximumConcurrentOperations: capacity["cluster_maximum_concurrent_operations"].(int),
+		CoreUtilizationCoefficient:         capacity["core_utilization_coefficient"].(float64),
+	}
+}
+
+func flattenOperationsCapacity(capacity *OperationsCapacity) []interface{} {
+	if capacity == nil {
+		return []interface{}{}
+	}
+	return []interface{}{map[string]interface{}{
+		"cluster_maximum_concurrent_operations": capacity.ClusterMaximumConcurrentOperations,
+		"core_utilization_coefficient":          capacity.CoreUtilizationCoefficient,
+	}}
+}
@@ -0,0 +1,49 @@
+package adx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXClusterCapacityPolicy_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	f.cluster.policies["capacity"] = `{"IngestionCapacity":{"ClusterMaximumConcurrentOperations":512,"CoreUtilizationCoefficient":0.75},"ExtentsMergeCapacity":{"MinimumConcurrentOperationsPerNode":1,"MaximumConcurrentOperationsPerNode":5},"ExportCapacity":{"ClusterMaximumConcurrentOperations":100,"CoreUtilizationCoefficient":0.25},"QueryAccelerationCapacity":{"ClusterMaximumConcurrentOperations":100,"CoreUtilizationCoefficient":0.5}}`
+	r := newFakeResource(t, f, resourceADXClusterCapacityPolicy())
+
+	state := r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"ingestion_capacity": []interface{}{map[string]interface{}{
+			"cluster_maximum_concurrent_operations": 256,
+			"core_utilization_coefficient":          0.5,
+		}},
+	})
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|cluster|capacity", f.endpoint()), state.ID)
+	assert.Equal(t, "256", state.Attributes["ingestion_capacity.0.cluster_maximum_concurrent_operations"])
+	assert.Equal(t, "100", state.Attributes["export_capacity.0.cluster_maximum_concurrent_operations"])
+
+	state = r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"ingestion_capacity": []interface{}{map[string]interface{}{
+			"cluster_maximum_concurrent_operations": 256,
+			"core_utilization_coefficient":          0.5,
+		}},
+		"extents_merge_capacity": []interface{}{map[string]interface{}{
+			"minimum_concurrent_operations_per_node": 2,
+			"maximum_concurrent_operations_per_node": 8,
+		}},
+	})
+	assert.Equal(t, "8", state.Attributes["extents_merge_capacity.0.maximum_concurrent_operations_per_node"])
+
+	r.checkImport(f)
+
+	r.destroy()
+	assert.Equal(t, []string{
+		".alter-merge cluster policy capacity '{\"IngestionCapacity\":{\"ClusterMaximumConcurrentOperations\":256,\"CoreUtilizationCoefficient\":0.5}}'",
+		".alter-merge cluster policy capacity '{\"ExtentsMergeCapacity\":{\"MinimumConcurrentOperationsPerNode\":2,\"MaximumConcurrentOperationsPerNode\":8}}'",
+	}, f.controlCommands())
+	// the capacity policy cannot be deleted, unmanaged capacities are left untouched
+	assert.Contains(t, f.cluster.policies["capacity"], `"QueryAccelerationCapacity":{"ClusterMaximumConcurrentOperations":100`)
+	assert.Contains(t, f.cluster.policies["capacity"], `"ExportCapacity":{"ClusterMaximumConcurrentOperations":100`)
+}
@@ -0,0 +1,204 @@
+package adx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
+	"github.com/favoretti/terraform-provider-adx/adx/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceADXClusterPrincipal() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceADXClusterPrincipalCreate,
+		ReadContext:   resourceADXClusterPrincipalRead,
+		UpdateContext: resourceADXClusterPrincipalUpdate,
+		DeleteContext: resourceADXClusterPrincipalDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster": getClusterConfigInputSchema(),
+			"database_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+				Description:      "Database name used as context for the management command. Cluster principals are cluster-level resources.",
+			},
+
+			"role": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"AllDatabasesAdmin",
+					"AllDatabasesViewer",
+					"AllDatabasesMonitor",
+				}, false),
+			},
+
+			"principal_fqn": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+				DiffSuppressFunc: suppressPrincipalFQNDiff,
+				Description:      "Fully qualified name of the principal, e.g. 'aaduser=user@example.com' or 'aadapp=<app-id>;<tenant>'",
+			},
+
+			"notes": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Free text notes describing the role assignment",
+			},
+
+			"principal_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Type of the principal (e.g., AAD User, AAD App, AAD Group)",
+			},
+
+			"principal_display_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Display name of the principal",
+			},
+		},
+		CustomizeDiff: clusterConfigCustomDiff,
+	}
+}
+
+func resourceADXClusterPrincipalCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clusterConfig := getAndExpandClusterConfigWithDefaults(ctx, d, meta)
+
+	databaseName := d.Get("database_name").(string)
+	role := d.Get("role").(string)
+	principalFQN := d.Get("principal_fqn").(string)
+	notes := d.Get("notes").(string)
+
+	client, err := getADXClient(meta, clusterConfig)
+	if err != nil {
+		return diag.Errorf("error creating adx client connection: %+v", err)
+	}
+
+	addStatement := buildClusterPrincipalStatement(".add", role, principalFQN, notes)
+
+	// Like the other .add commands, this returns the principals of the cluster with their resolved FQN
+	resultSet, err := queryADXMgmtAndParse[TablePrincipal](ctx, meta, clusterConfig, databaseName, addStatement)
+	if err != nil {
+		return diag.Errorf("error adding %s role for principal %q on cluster: %+v", role, principalFQN, err)
+	}
+
+	actualFQN := resolvedPrincipalFQN(resultSet, role, principalFQN)
+
+	d.SetId(buildADXResourceId(client.Endpoint(), databaseName, "cluster_principal", role, actualFQN))
+
+	return resourceADXClusterPrincipalRead(ctx, d, meta)
+}
+
+func resourceADXClusterPrincipalRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	clusterConfig := getAndExpandClusterConfigWithDefaults(ctx, d, meta)
+
+	id, err := parseADXClusterPrincipalID(d.Id())
+	if err != nil {
+		return diag.Errorf("error parsing resource ID: %+v", err)
+	}
+
+	showStatement := kql.New(".show", "cluster", "principals").String()
+
+	resultSet, err := queryADXMgmtAndParse[TablePrincipal](ctx, meta, clusterConfig, id.DatabaseName, showStatement)
+	if err != nil {
+		return diag.Errorf("error reading cluster principals: %+v", err)
+	}
+
+	found := findPrincipal(resultSet, id.Role, id.PrincipalFQN)
+	if found == nil {
+		d.SetId("")
+		return diags
+	}
+
+	d.Set("database_name", id.DatabaseName)
+	d.Set("role", id.Role)
+	d.Set("principal_fqn", found.PrincipalFQN)
+	d.Set("principal_type", found.PrincipalType)
+	d.Set("principal_display_name", found.PrincipalDisplayName)
+	d.Set("notes", found.Notes)
+
+	return diags
+}
+
+func resourceADXClusterPrincipalUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clusterConfig := getAndExpandClusterConfigWithDefaults(ctx, d, meta)
+
+	id, err := parseADXClusterPrincipalID(d.Id())
+	if err != nil {
+		return diag.Errorf("error parsing resource ID: %+v", err)
+	}
+
+	notes := d.Get("notes").(string)
+
+	dropStatement := buildClusterPrincipalStatement(".drop", id.Role, id.PrincipalFQN, "")
+	_, err = queryADXMgmt(ctx, meta, clusterConfig, id.DatabaseName, dropStatement)
+	if err != nil {
+		return diag.Errorf("error dropping %s role for principal %q on cluster during update: %+v", id.Role, id.PrincipalFQN, err)
+	}
+
+	addStatement := buildClusterPrincipalStatement(".add", id.Role, id.PrincipalFQN, notes)
+	_, err = queryADXMgmt(ctx, meta, clusterConfig, id.DatabaseName, addStatement)
+	if err != nil {
+		return diag.Errorf("error re-adding %s role for principal %q on cluster during update: %+v", id.Role, id.PrincipalFQN, err)
+	}
+
+	return resourceADXClusterPrincipalRead(ctx, d, meta)
+}
+
+func resourceADXClusterPrincipalDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clusterConfig := getAndExpandClusterConfigWithDefaults(ctx, d, meta)
+
+	id, err := parseADXClusterPrincipalID(d.Id())
+	if err != nil {
+		return diag.Errorf("error parsing resource ID: %+v", err)
+	}
+
+	dropStatement := buildClusterPrincipalStatement(".drop", id.Role, id.PrincipalFQN, "")
+
+	return deleteADXEntity(ctx, d, meta, clusterConfig, id.DatabaseName, dropStatement)
+}
+
+// buildClusterPrincipalStatement builds the .add or .drop command for a single cluster principal
+func buildClusterPrincipalStatement(cmd string, role string, principalFQN string, notes string) string {
+	statement := kql.New(cmd, "cluster", role).StringList(principalFQN)
+	if notes != "" {
+		statement.StringLiteral(notes)
+	}
+	return statement.String()
+}
+
+type adxClusterPrincipalResourceId struct {
+	EndpointURI  string
+	DatabaseName string
+	Role         string
+	PrincipalFQN string
+}
+
+func parseADXClusterPrincipalID(input string) (*adxClusterPrincipalResourceId, error) {
+	parts := strings.Split(input, "|")
+	if len(parts) != 5 || parts[2] != "cluster_principal" {
+		return nil, fmt.Errorf("error parsing ADX Cluster Principal resource ID: unexpected format: %q, expected <cluster>|<database>|cluster_principal|<role>|<principal_fqn>", input)
+	}
+
+	return &adxClusterPrincipalResourceId{
+		EndpointURI:  parts[0],
+		DatabaseName: parts[1],
+		Role:         parts[3],
+		PrincipalFQN: parts[4],
+	}, nil
+}
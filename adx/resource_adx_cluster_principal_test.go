@@ -0,0 +1,42 @@
+package adx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXClusterPrincipal_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	r := newFakeResource(t, f, resourceADXClusterPrincipal())
+
+	config := map[string]interface{}{
+		"database_name": "fake_db",
+		"role":          "AllDatabasesViewer",
+		"principal_fqn": "aaduser=auditor@example.com",
+		"notes":         "fake auditor",
+	}
+	state := r.apply(config)
+	resolvedFQN := resolvePrincipalFQN("aaduser=auditor@example.com")
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|cluster_principal|AllDatabasesViewer|%s", f.endpoint(), resolvedFQN), state.ID)
+	assert.Equal(t, resolvedFQN, state.Attributes["principal_fqn"])
+	assert.Equal(t, "AAD User", state.Attributes["principal_type"])
+	assert.Equal(t, "Fake Principal (upn: auditor@example.com)", state.Attributes["principal_display_name"])
+
+	config["notes"] = "updated notes"
+	state = r.apply(config)
+	assert.Equal(t, "updated notes", state.Attributes["notes"])
+
+	r.checkImport(f)
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Empty(t, f.cluster.principals)
+	assert.Equal(t, []string{
+		".add cluster AllDatabasesViewer ('aaduser=auditor@example.com') 'fake auditor'",
+		fmt.Sprintf(".drop cluster AllDatabasesViewer ('%s')", resolvedFQN),
+		fmt.Sprintf(".add cluster AllDatabasesViewer ('%s') 'updated notes'", resolvedFQN),
+		fmt.Sprintf(".drop cluster AllDatabasesViewer ('%s')", resolvedFQN),
+	}, f.controlCommands())
+}
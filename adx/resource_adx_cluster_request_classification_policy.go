@@ -3,11 +3,9 @@ package adx
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"strings"
 
-	"github.com/Azure/azure-kusto-go/kusto"
-	"github.com/Azure/azure-kusto-go/kusto/unsafe"
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
 	"github.com/favoretti/terraform-provider-adx/adx/validate"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -69,16 +67,18 @@ func resourceADXClusterRequestClassificationPolicyCreateUpdate(ctx context.Conte
 	isEnabled := d.Get("is_enabled").(bool)
 	classificationFunction := d.Get("classification_function").(string)
 
-	policyJSON := fmt.Sprintf(`{"IsEnabled":%t}`, isEnabled)
-	createStatement := fmt.Sprintf(".alter cluster policy request_classification '%s' <|\n%s", policyJSON, classificationFunction)
+	policyJSON, err := kql.JSON(requestClassificationPolicyObject{IsEnabled: isEnabled})
+	if err != nil {
+		return diag.Errorf("error serializing cluster request classification policy: %+v", err)
+	}
+	createStatement := kql.New(".alter", "cluster", "policy", "request_classification").Literal(policyJSON).Keyword("<|").Query(classificationFunction).String()
 
 	client, err := getADXClient(meta, clusterConfig)
 	if err != nil {
 		return diag.Errorf("error creating adx client connection: %+v", err)
 	}
 
-	kStmtOpts := kusto.UnsafeStmt(unsafe.Stmt{Add: true})
-	_, err = client.Mgmt(ctx, databaseName, kusto.NewStmt("", kStmtOpts).UnsafeAdd(createStatement))
+	_, err = executeADXMgmt(ctx, meta, client, databaseName, createStatement)
 	if err != nil {
 		return diag.Errorf("error creating/updating cluster request classification policy (Database %q): %+v", databaseName, err)
 	}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 type ADXClusterRequestClassificationPolicyTestResource struct{}
@@ -133,3 +134,34 @@ func (this ADXClusterRequestClassificationPolicyTestResource) checkDestroy(datab
 		return nil
 	}
 }
+
+func TestFakeADXClusterRequestClassificationPolicy_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	r := newFakeResource(t, f, resourceADXClusterRequestClassificationPolicy())
+
+	state := r.apply(map[string]interface{}{
+		"database_name":           "fake_db",
+		"is_enabled":              true,
+		"classification_function": "iff(request_properties.current_application == 'Kusto.Explorer', 'adhoc', 'default')",
+	})
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|cluster|request_classification", f.endpoint()), state.ID)
+	assert.Equal(t, "true", state.Attributes["is_enabled"])
+
+	state = r.apply(map[string]interface{}{
+		"database_name":           "fake_db",
+		"is_enabled":              false,
+		"classification_function": "'default'",
+	})
+	assert.Equal(t, "false", state.Attributes["is_enabled"])
+	assert.Equal(t, "'default'", state.Attributes["classification_function"])
+
+	r.checkImport(f)
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Equal(t, []string{
+		".alter cluster policy request_classification '{\"IsEnabled\":true}' <| iff(request_properties.current_application == 'Kusto.Explorer', 'adhoc', 'default')",
+		".alter cluster policy request_classification '{\"IsEnabled\":false}' <| 'default'",
+		".delete cluster policy request_classification",
+	}, f.controlCommands())
+}
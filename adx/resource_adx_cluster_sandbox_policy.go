@@ -0,0 +1,196 @@
+package adx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/favoretti/terraform-provider-adx/adx/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type SandboxPolicyEntry struct {
+	SandboxKind           string `json:"SandboxKind"`
+	IsEnabled             bool   `json:"IsEnabled"`
+	TargetCountPerNode    int    `json:"TargetCountPerNode,omitempty"`
+	MaxCpuRatePerSandbox  int    `json:"MaxCpuRatePerSandbox,omitempty"`
+	MaxMemoryMbPerSandbox int    `json:"MaxMemoryMbPerSandbox,omitempty"`
+}
+
+func resourceADXClusterSandboxPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceADXClusterSandboxPolicyCreateUpdate,
+		ReadContext:   resourceADXClusterSandboxPolicyRead,
+		UpdateContext: resourceADXClusterSandboxPolicyCreateUpdate,
+		DeleteContext: resourceADXClusterSandboxPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster": getClusterConfigInputSchema(),
+			"database_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+				Description:      "Database name used as context for the management command. The policy is cluster-level.",
+			},
+			"sandbox": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				MaxItems:    2,
+				Description: "Sandboxes managed by this resource. Sandbox kinds not listed here are left untouched.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"kind": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validate.StringInSlice([]string{"PythonExecution", "RExecution"}),
+							Description:      "Kind of the sandbox, PythonExecution for the python plugin or RExecution for the r plugin",
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+						"target_count_per_node": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "Number of sandboxes prepared on each node",
+						},
+						"max_cpu_rate_per_sandbox": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IntBetween(1, 100),
+							Description:  "Maximum CPU rate, in percent, a single sandbox may use",
+						},
+						"max_memory_mb_per_sandbox": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "Maximum memory, in MB, a single sandbox may use",
+						},
+					},
+				},
+			},
+		},
+		CustomizeDiff: clusterConfigCustomDiff,
+	}
+}
+
+func resourceADXClusterSandboxPolicyCreateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.IsNewResource() {
+		removed := removedClusterPolicyEntries(d, "sandbox", sandboxEntryKeyFromState)
+		if diags := removeClusterPolicyEntries(ctx, d, meta, "sandbox", sandboxEntryKey, removed); diags.HasError() {
+			return diags
+		}
+	}
+
+	var entries []SandboxPolicyEntry
+	for _, v := range d.Get("sandbox").([]interface{}) {
+		sandbox := v.(map[string]interface{})
+		entries = append(entries, SandboxPolicyEntry{
+			SandboxKind:           sandbox["kind"].(string),
+			IsEnabled:             sandbox["enabled"].(bool),
+			TargetCountPerNode:    sandbox["target_count_per_node"].(int),
+			MaxCpuRatePerSandbox:  sandbox["max_cpu_rate_per_sandbox"].(int),
+			MaxMemoryMbPerSandbox: sandbox["max_memory_mb_per_sandbox"].(int),
+		})
+	}
+
+	if diags := alterMergeClusterPolicy(ctx, d, meta, "sandbox", entries); diags.HasError() {
+		return diags
+	}
+
+	return resourceADXClusterSandboxPolicyRead(ctx, d, meta)
+}
+
+func resourceADXClusterSandboxPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id, entries, diags := readClusterPolicyEntries(ctx, d, meta, "sandbox")
+	if diags.HasError() {
+		return diags
+	}
+
+	policyEntries := map[string]map[string]interface{}{}
+	for _, entry := range entries {
+		policyEntries[sandboxEntryKey(entry)] = entry
+	}
+
+	// only the managed sandboxes are read back, all of them when the resource is imported
+	var managed []string
+	for _, v := range d.Get("sandbox").([]interface{}) {
+		if v != nil {
+			managed = append(managed, sandboxEntryKeyFromState(v.(map[string]interface{})))
+		}
+	}
+	if len(managed) == 0 {
+		for _, entry := range entries {
+			managed = append(managed, sandboxEntryKey(entry))
+		}
+	}
+
+	sandboxes := make([]interface{}, 0, len(managed))
+	for _, key := range managed {
+		entry, ok := policyEntries[key]
+		if !ok {
+			continue
+		}
+		enabled, _ := entry["IsEnabled"].(bool)
+		sandboxes = append(sandboxes, map[string]interface{}{
+			"kind":                      key,
+			"enabled":                   enabled,
+			"target_count_per_node":     jsonNumberToInt(entry["TargetCountPerNode"]),
+			"max_cpu_rate_per_sandbox":  jsonNumberToInt(entry["MaxCpuRatePerSandbox"]),
+			"max_memory_mb_per_sandbox": jsonNumberToInt(entry["MaxMemoryMbPerSandbox"]),
+		})
+	}
+
+	if len(sandboxes) == 0 {
+		d.SetId("")
+		return diags
+	}
+
+	d.Set("database_name", id.DatabaseName)
+	d.Set("sandbox", sandboxes)
+
+	return diags
+}
+
+func resourceADXClusterSandboxPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	removed := map[string]bool{}
+	for _, v := range d.Get("sandbox").([]interface{}) {
+		if v != nil {
+			removed[sandboxEntryKeyFromState(v.(map[string]interface{}))] = true
+		}
+	}
+
+	if diags := removeClusterPolicyEntries(ctx, d, meta, "sandbox", sandboxEntryKey, removed); diags.HasError() {
+		return diags
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// sandboxEntryKey identifies a sandbox policy entry by its kind
+func sandboxEntryKey(entry map[string]interface{}) string {
+	return fmt.Sprint(entry["SandboxKind"])
+}
+
+func sandboxEntryKeyFromState(sandbox map[string]interface{}) string {
+	return sandbox["kind"].(string)
+}
+
+// jsonNumberToInt returns the integer value of a number decoded from JSON, or 0 when it is missing
+func jsonNumberToInt(v interface{}) int {
+	if n, ok := v.(float64); ok {
+		return int(n)
+	}
+	return 0
+}
@@ -0,0 +1,56 @@
+package adx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXClusterSandboxPolicy_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	f.cluster.policies["sandbox"] = `[{"SandboxKind":"RExecution","IsEnabled":false,"TargetCountPerNode":2,"MaxCpuRatePerSandbox":50,"MaxMemoryMbPerSandbox":10240}]`
+	r := newFakeResource(t, f, resourceADXClusterSandboxPolicy())
+
+	state := r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"sandbox": []interface{}{
+			map[string]interface{}{"kind": "PythonExecution", "enabled": true, "target_count_per_node": 4, "max_cpu_rate_per_sandbox": 50, "max_memory_mb_per_sandbox": 20480},
+		},
+	})
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|cluster|sandbox", f.endpoint()), state.ID)
+	assert.Equal(t, "1", state.Attributes["sandbox.#"])
+	assert.Equal(t, "20480", state.Attributes["sandbox.0.max_memory_mb_per_sandbox"])
+
+	state = r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"sandbox": []interface{}{
+			map[string]interface{}{"kind": "PythonExecution", "enabled": true, "target_count_per_node": 8, "max_cpu_rate_per_sandbox": 50, "max_memory_mb_per_sandbox": 20480},
+		},
+	})
+	assert.Equal(t, "8", state.Attributes["sandbox.0.target_count_per_node"])
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Equal(t, []string{
+		`.alter-merge cluster policy sandbox '[{"SandboxKind":"PythonExecution","IsEnabled":true,"TargetCountPerNode":4,"MaxCpuRatePerSandbox":50,"MaxMemoryMbPerSandbox":20480}]'`,
+		`.alter-merge cluster policy sandbox '[{"SandboxKind":"PythonExecution","IsEnabled":true,"TargetCountPerNode":8,"MaxCpuRatePerSandbox":50,"MaxMemoryMbPerSandbox":20480}]'`,
+		`.alter cluster policy sandbox '[{"IsEnabled":false,"MaxCpuRatePerSandbox":50,"MaxMemoryMbPerSandbox":10240,"SandboxKind":"RExecution","TargetCountPerNode":2}]'`,
+	}, f.controlCommands())
+	// the R sandbox is not managed by the resource and is left untouched
+	assert.Contains(t, f.cluster.policies["sandbox"], `"SandboxKind":"RExecution"`)
+	assert.NotContains(t, f.cluster.policies["sandbox"], `"SandboxKind":"PythonExecution"`)
+}
+
+func TestFakeADXClusterSandboxPolicy_import(t *testing.T) {
+	f := newFakeKusto(t)
+	r := newFakeResource(t, f, resourceADXClusterSandboxPolicy())
+
+	r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"sandbox": []interface{}{
+			map[string]interface{}{"kind": "PythonExecution", "enabled": true},
+		},
+	})
+	r.checkImport(f)
+}
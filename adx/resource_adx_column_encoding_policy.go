@@ -2,10 +2,10 @@ package adx
 
 import (
 	"context"
-	"fmt"
 
 	"encoding/json"
 
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
 	"github.com/favoretti/terraform-provider-adx/adx/validate"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -57,7 +57,8 @@ func resourceADXColumnEncodingPolicyCreateUpdate(ctx context.Context, d *schema.
 	entityIdentifier := d.Get("entity_identifier").(string)
 	encodingPolicyType := d.Get("encoding_policy_type").(string)
 
-	createStatement := fmt.Sprintf(".alter column %s policy encoding type='%s'", entityIdentifier, encodingPolicyType)
+	createStatement := kql.New(".alter", "column").QualifiedIdentifier(splitColumnReference(entityIdentifier)...).
+		Keyword("policy", "encoding").Literal("type=" + kql.StringLiteral(encodingPolicyType)).String()
 
 	if err := createADXPolicy(ctx, d, meta, "column", "encoding", databaseName, entityIdentifier, createStatement); err != nil {
 		return diag.Errorf("%+v", err)
@@ -93,5 +94,7 @@ func resourceADXColumnEncodingPolicyDelete(ctx context.Context, d *schema.Resour
 		return diag.Errorf("could not delete adx policy due to error parsing ID: %+v", err)
 	}
 
-	return deleteADXEntity(ctx, d, meta, clusterConfig, id.DatabaseName, fmt.Sprintf(".alter column %s policy encoding type='Null'", entityIdentifier)) //Encoding policy can't be deleted. So set it back to default.
+	deleteStatement := kql.New(".alter", "column").QualifiedIdentifier(splitColumnReference(entityIdentifier)...).
+		Keyword("policy", "encoding").Literal("type=" + kql.StringLiteral("Null")).String()
+	return deleteADXEntity(ctx, d, meta, clusterConfig, id.DatabaseName, deleteStatement) //Encoding policy can't be deleted. So set it back to default.
 }
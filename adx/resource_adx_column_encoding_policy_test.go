@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 type ADXColumnEncodingPolicyTestResource struct{}
@@ -70,3 +71,42 @@ func (this ADXColumnEncodingPolicyTestResource) template(rtc *ResourceTestContex
 	}
 	`, rtc.DatabaseName, rtc.EntityName)
 }
+
+func TestFakeADXColumnEncodingPolicy_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db").addTable("fake_table", "f1:string,f2:string")
+	r := newFakeResource(t, f, resourceADXColumnEncodingPolicy())
+
+	state := r.apply(map[string]interface{}{
+		"database_name":        "fake_db",
+		"entity_identifier":    "fake_table.f1",
+		"encoding_policy_type": "BigObject",
+	})
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|column|fake_table.f1|policy|encoding", f.endpoint()), state.ID)
+	assert.Equal(t, "BigObject", state.Attributes["encoding_policy_type"])
+
+	state = r.apply(map[string]interface{}{
+		"database_name":        "fake_db",
+		"entity_identifier":    "fake_table.f1",
+		"encoding_policy_type": "Identifier",
+	})
+	assert.Equal(t, "Identifier", state.Attributes["encoding_policy_type"])
+
+	r.checkImport(f)
+
+	// the encoding policy cannot be deleted, destroying it resets the column to the default encoding
+	r.destroy()
+	assert.Empty(t, f.database("fake_db").policies)
+	assert.Equal(t, []string{
+		".alter column fake_table.f1 policy encoding type='BigObject'",
+		".alter column fake_table.f1 policy encoding type='Identifier'",
+		".alter column fake_table.f1 policy encoding type='Null'",
+	}, f.controlCommands())
+}
+
+func TestADXColumnEncodingPolicy_splitColumnReference(t *testing.T) {
+	assert.Equal(t, []string{"Events", "Payload"}, splitColumnReference("Events.Payload"))
+	assert.Equal(t, []string{"['my.table']", "col"}, splitColumnReference("['my.table'].col"))
+	assert.Equal(t, []string{`['it\'s.table']`, "['my.col']"}, splitColumnReference(`['it\'s.table'].['my.col']`))
+	assert.Equal(t, []string{"Events"}, splitColumnReference("Events"))
+}
@@ -0,0 +1,59 @@
+package adx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXDatabaseCachingPolicy_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db")
+	r := newFakeResource(t, f, resourceADXDatabaseCachingPolicy())
+
+	state := r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"data_hot_span": "30d",
+	})
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|database|fake_db|policy|caching", f.endpoint()), state.ID)
+	assert.Equal(t, "30d", state.Attributes["data_hot_span"])
+
+	state = r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"data_hot_span": "36h",
+	})
+	assert.Equal(t, "36h", state.Attributes["data_hot_span"])
+	assert.JSONEq(t, `{"DataHotSpan":{"Value":"1.12:00:00"},"IndexHotSpan":{"Value":"1.12:00:00"}}`, f.database("fake_db").policies["database|fake_db|caching"])
+
+	r.checkImport(f, "data_hot_span", "follower_database")
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Equal(t, []string{
+		".alter database fake_db policy caching hot = 30d",
+		".alter database fake_db policy caching hot = 36h",
+		".delete database fake_db policy caching",
+	}, f.controlCommands())
+}
+
+func TestFakeADXDatabaseCachingPolicy_follower(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_follower")
+	r := newFakeResource(t, f, resourceADXDatabaseCachingPolicy())
+
+	state := r.apply(map[string]interface{}{
+		"database_name":     "fake_follower",
+		"data_hot_span":     "7d",
+		"follower_database": true,
+	})
+	assert.Equal(t, "7d", state.Attributes["data_hot_span"])
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Empty(t, f.database("fake_follower").policies)
+	assert.Equal(t, []string{
+		".alter follower database fake_follower policy caching hot = 7d",
+		".delete follower database fake_follower policy caching",
+	}, f.controlCommands())
+}
@@ -0,0 +1,109 @@
+package adx
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
+	"github.com/favoretti/terraform-provider-adx/adx/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type DatabaseIngestionBatchingPolicy struct {
+	MaximumBatchingTimeSpan string
+	MaximumNumberOfItems    int
+	MaximumRawDataSizeMB    int
+}
+
+func resourceADXDatabaseIngestionBatchingPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceADXDatabaseIngestionBatchingPolicyCreateUpdate,
+		ReadContext:   resourceADXDatabaseIngestionBatchingPolicyRead,
+		DeleteContext: resourceADXDatabaseIngestionBatchingPolicyDelete,
+		UpdateContext: resourceADXDatabaseIngestionBatchingPolicyCreateUpdate,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster": getClusterConfigInputSchema(),
+			"database_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+			},
+
+			"max_batching_timespan": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateDiagFunc: validate.StringMatch(
+					regexp.MustCompile("\\d\\d:\\d\\d:\\d\\d"),
+					"batching timespan must be in the format HH:MM:SS of ex. 00:10:00 for 10 minutes",
+				),
+			},
+
+			"max_items": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+
+			"max_raw_size_mb": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+		},
+		CustomizeDiff: clusterConfigCustomDiff,
+	}
+}
+
+func resourceADXDatabaseIngestionBatchingPolicyCreateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	databaseName := d.Get("database_name").(string)
+
+	policyJSON, err := kql.JSON(DatabaseIngestionBatchingPolicy{
+		MaximumBatchingTimeSpan: d.Get("max_batching_timespan").(string),
+		MaximumNumberOfItems:    d.Get("max_items").(int),
+		MaximumRawDataSizeMB:    d.Get("max_raw_size_mb").(int),
+	})
+	if err != nil {
+		return diag.Errorf("error serializing policy ingestionbatching for Database %q: %+v", databaseName, err)
+	}
+
+	createStatement := kql.New(".alter", "database").Identifier(databaseName).Keyword("policy", "ingestionbatching").Literal(policyJSON).String()
+
+	if err := createADXPolicy(ctx, d, meta, "database", "ingestionbatching", databaseName, databaseName, createStatement); err != nil {
+		return diag.Errorf("%+v", err)
+	}
+
+	return resourceADXDatabaseIngestionBatchingPolicyRead(ctx, d, meta)
+}
+
+func resourceADXDatabaseIngestionBatchingPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id, resultSet, diags := readADXPolicy(ctx, d, meta, "database", "ingestionbatching")
+	if diags.HasError() || resultSet == nil || len(resultSet) == 0 {
+		return diags
+	}
+
+	if resultSet[0].Policy == "null" {
+		d.SetId("")
+		return diags
+	}
+
+	var policy DatabaseIngestionBatchingPolicy
+	if err := json.Unmarshal([]byte(resultSet[0].Policy), &policy); err != nil {
+		return diag.Errorf("error parsing policy ingestionbatching for Database %q: %+v", id.DatabaseName, err)
+	}
+
+	d.Set("database_name", id.DatabaseName)
+	d.Set("max_batching_timespan", policy.MaximumBatchingTimeSpan)
+	d.Set("max_items", policy.MaximumNumberOfItems)
+	d.Set("max_raw_size_mb", policy.MaximumRawDataSizeMB)
+
+	return diags
+}
+
+func resourceADXDatabaseIngestionBatchingPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return deleteADXPolicy(ctx, d, meta, "database", "ingestionbatching")
+}
@@ -0,0 +1,33 @@
+package adx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXDatabaseIngestionBatchingPolicy_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db")
+	r := newFakeResource(t, f, resourceADXDatabaseIngestionBatchingPolicy())
+
+	config := map[string]interface{}{
+		"database_name":         "fake_db",
+		"max_batching_timespan": "00:05:00",
+		"max_items":             500,
+		"max_raw_size_mb":       1024,
+	}
+	state := r.apply(config)
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|database|fake_db|policy|ingestionbatching", f.endpoint()), state.ID)
+
+	config["max_batching_timespan"] = "00:01:00"
+	state = r.apply(config)
+	assert.Equal(t, "00:01:00", state.Attributes["max_batching_timespan"])
+
+	r.checkImport(f)
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Empty(t, f.database("fake_db").policies)
+}
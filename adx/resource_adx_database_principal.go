@@ -0,0 +1,216 @@
+package adx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
+	"github.com/favoretti/terraform-provider-adx/adx/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var databasePrincipalRoles = []string{
+	"admins",
+	"users",
+	"viewers",
+	"unrestrictedviewers",
+	"ingestors",
+	"monitors",
+}
+
+func resourceADXDatabasePrincipal() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceADXDatabasePrincipalCreate,
+		ReadContext:   resourceADXDatabasePrincipalRead,
+		UpdateContext: resourceADXDatabasePrincipalUpdate,
+		DeleteContext: resourceADXDatabasePrincipalDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster": getClusterConfigInputSchema(),
+			"database_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+			},
+
+			"role": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(databasePrincipalRoles, false),
+			},
+
+			"principal_fqn": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+				DiffSuppressFunc: suppressPrincipalFQNDiff,
+				Description:      "Fully qualified name of the principal, e.g. 'aaduser=user@example.com' or 'aadapp=<app-id>;<tenant>'",
+			},
+
+			"notes": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Free text notes describing the role assignment",
+			},
+
+			"principal_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Type of the principal (e.g., AAD User, AAD App, AAD Group)",
+			},
+
+			"principal_display_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Display name of the principal",
+			},
+		},
+		CustomizeDiff: clusterConfigCustomDiff,
+	}
+}
+
+func resourceADXDatabasePrincipalCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clusterConfig := getAndExpandClusterConfigWithDefaults(ctx, d, meta)
+
+	databaseName := d.Get("database_name").(string)
+	role := d.Get("role").(string)
+	principalFQN := d.Get("principal_fqn").(string)
+	notes := d.Get("notes").(string)
+
+	client, err := getADXClient(meta, clusterConfig)
+	if err != nil {
+		return diag.Errorf("error creating adx client connection: %+v", err)
+	}
+
+	addStatement := buildDatabasePrincipalStatement(".add", databaseName, role, principalFQN, notes)
+
+	// Like .add table, the command returns the principals of the database with their resolved FQN
+	resultSet, err := queryADXMgmtAndParse[TablePrincipal](ctx, meta, clusterConfig, databaseName, addStatement)
+	if err != nil {
+		return diag.Errorf("error adding %s role for principal %q on database %q: %+v", role, principalFQN, databaseName, err)
+	}
+
+	actualFQN := resolvedPrincipalFQN(resultSet, role, principalFQN)
+
+	d.SetId(buildADXResourceId(client.Endpoint(), databaseName, "principal", role, actualFQN))
+
+	return resourceADXDatabasePrincipalRead(ctx, d, meta)
+}
+
+func resourceADXDatabasePrincipalRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	clusterConfig := getAndExpandClusterConfigWithDefaults(ctx, d, meta)
+
+	id, err := parseADXDatabasePrincipalID(d.Id())
+	if err != nil {
+		return diag.Errorf("error parsing resource ID: %+v", err)
+	}
+
+	if databaseExists, err := isDatabaseExists(ctx, meta, clusterConfig, id.DatabaseName); err != nil || !databaseExists {
+		if err != nil {
+			return diag.Errorf("%+v", err)
+		}
+		d.SetId("")
+		return diags
+	}
+
+	showStatement := kql.New(".show", "database").Identifier(id.DatabaseName).Keyword("principals").String()
+
+	resultSet, err := queryADXMgmtAndParse[TablePrincipal](ctx, meta, clusterConfig, id.DatabaseName, showStatement)
+	if err != nil {
+		return diag.Errorf("error reading principals for database %q: %+v", id.DatabaseName, err)
+	}
+
+	found := findPrincipal(resultSet, id.Role, id.PrincipalFQN)
+	if found == nil {
+		d.SetId("")
+		return diags
+	}
+
+	d.Set("database_name", id.DatabaseName)
+	d.Set("role", id.Role)
+	d.Set("principal_fqn", found.PrincipalFQN)
+	d.Set("principal_type", found.PrincipalType)
+	d.Set("principal_display_name", found.PrincipalDisplayName)
+	d.Set("notes", found.Notes)
+
+	return diags
+}
+
+func resourceADXDatabasePrincipalUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clusterConfig := getAndExpandClusterConfigWithDefaults(ctx, d, meta)
+
+	id, err := parseADXDatabasePrincipalID(d.Id())
+	if err != nil {
+		return diag.Errorf("error parsing resource ID: %+v", err)
+	}
+
+	notes := d.Get("notes").(string)
+
+	dropStatement := buildDatabasePrincipalStatement(".drop", id.DatabaseName, id.Role, id.PrincipalFQN, "")
+	_, err = queryADXMgmt(ctx, meta, clusterConfig, id.DatabaseName, dropStatement)
+	if err != nil {
+		return diag.Errorf("error dropping %s role for principal %q on database %q during update: %+v", id.Role, id.PrincipalFQN, id.DatabaseName, err)
+	}
+
+	addStatement := buildDatabasePrincipalStatement(".add", id.DatabaseName, id.Role, id.PrincipalFQN, notes)
+	_, err = queryADXMgmt(ctx, meta, clusterConfig, id.DatabaseName, addStatement)
+	if err != nil {
+		return diag.Errorf("error re-adding %s role for principal %q on database %q during update: %+v", id.Role, id.PrincipalFQN, id.DatabaseName, err)
+	}
+
+	return resourceADXDatabasePrincipalRead(ctx, d, meta)
+}
+
+func resourceADXDatabasePrincipalDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clusterConfig := getAndExpandClusterConfigWithDefaults(ctx, d, meta)
+
+	id, err := parseADXDatabasePrincipalID(d.Id())
+	if err != nil {
+		return diag.Errorf("error parsing resource ID: %+v", err)
+	}
+
+	dropStatement := buildDatabasePrincipalStatement(".drop", id.DatabaseName, id.Role, id.PrincipalFQN, "")
+
+	return deleteADXEntity(ctx, d, meta, clusterConfig, id.DatabaseName, dropStatement)
+}
+
+// buildDatabasePrincipalStatement builds the .add or .drop command for a single database principal
+func buildDatabasePrincipalStatement(cmd string, databaseName string, role string, principalFQN string, notes string) string {
+	statement := kql.New(cmd, "database").Identifier(databaseName).Keyword(role).StringList(principalFQN)
+	if notes != "" {
+		statement.StringLiteral(notes)
+	}
+	return statement.String()
+}
+
+type adxDatabasePrincipalResourceId struct {
+	EndpointURI  string
+	DatabaseName string
+	Role         string
+	PrincipalFQN string
+}
+
+func parseADXDatabasePrincipalID(input string) (*adxDatabasePrincipalResourceId, error) {
+	parts := strings.Split(input, "|")
+	if len(parts) != 5 || parts[2] != "principal" {
+		return nil, fmt.Errorf("error parsing ADX Database Principal resource ID: unexpected format: %q, expected <cluster>|<database>|principal|<role>|<principal_fqn>", input)
+	}
+
+	return &adxDatabasePrincipalResourceId{
+		EndpointURI:  parts[0],
+		DatabaseName: parts[1],
+		Role:         parts[3],
+		PrincipalFQN: parts[4],
+	}, nil
+}
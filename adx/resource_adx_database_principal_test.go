@@ -0,0 +1,66 @@
+package adx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXDatabasePrincipal_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	r := newFakeResource(t, f, resourceADXDatabasePrincipal())
+
+	config := map[string]interface{}{
+		"database_name": "fake_db",
+		"role":          "viewers",
+		"principal_fqn": "aadgroup=readers@example.com",
+		"notes":         "fake viewers",
+	}
+	state := r.apply(config)
+	resolvedFQN := resolvePrincipalFQN("aadgroup=readers@example.com")
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|principal|viewers|%s", f.endpoint(), resolvedFQN), state.ID)
+	assert.Equal(t, resolvedFQN, state.Attributes["principal_fqn"])
+	assert.Equal(t, "AAD Group", state.Attributes["principal_type"])
+	assert.Equal(t, "Fake Principal (upn: readers@example.com)", state.Attributes["principal_display_name"])
+
+	config["notes"] = "updated notes"
+	state = r.apply(config)
+	assert.Equal(t, "updated notes", state.Attributes["notes"])
+
+	r.checkImport(f)
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Empty(t, f.database("fake_db").principals)
+	assert.Equal(t, []string{
+		".add database fake_db viewers ('aadgroup=readers@example.com') 'fake viewers'",
+		fmt.Sprintf(".drop database fake_db viewers ('%s')", resolvedFQN),
+		fmt.Sprintf(".add database fake_db viewers ('%s') 'updated notes'", resolvedFQN),
+		fmt.Sprintf(".drop database fake_db viewers ('%s')", resolvedFQN),
+	}, f.controlCommands())
+}
+
+func TestFakeADXDatabasePrincipal_roles(t *testing.T) {
+	f := newFakeKusto(t)
+	app := "aadapp=4c7e82bd-6adb-46c3-b413-fdd44834c69b;" + fakeKustoTenantID
+
+	unrestricted := newFakeResource(t, f, resourceADXDatabasePrincipal())
+	unrestricted.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"role":          "unrestrictedviewers",
+		"principal_fqn": app,
+	})
+
+	viewer := newFakeResource(t, f, resourceADXDatabasePrincipal())
+	viewer.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"role":          "viewers",
+		"principal_fqn": app,
+	})
+	assert.Len(t, f.database("fake_db").principals, 2)
+
+	viewer.destroy()
+	viewer.checkDestroyed()
+	assert.NotNil(t, unrestricted.refresh(), "dropping the viewers role should keep the unrestrictedviewers role")
+}
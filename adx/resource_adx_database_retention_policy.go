@@ -0,0 +1,121 @@
+package adx
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
+	"github.com/favoretti/terraform-provider-adx/adx/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type DatabaseRetentionPolicy struct {
+	SoftDeletePeriod string
+	Recoverability   string
+}
+
+func resourceADXDatabaseRetentionPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceADXDatabaseRetentionPolicyCreateUpdate,
+		ReadContext:   resourceADXDatabaseRetentionPolicyRead,
+		DeleteContext: resourceADXDatabaseRetentionPolicyDelete,
+		UpdateContext: resourceADXDatabaseRetentionPolicyCreateUpdate,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster": getClusterConfigInputSchema(),
+			"database_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+			},
+
+			"soft_delete_period": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateDiagFunc: validate.StringMatch(
+					regexp.MustCompile(`^([1-9]\d{0,4}|[1-2]\d{5}|3[0-4]\d{4}|35\d{4}|36[0-3]\d{3}|364[0-5]\d{2}|3646[0-2]\d|36463[0-5])[dhms]$`),
+					"soft delete timespan must be in the format of <amount><unit> such as 1m for (one minute) or 30d (thirty days), maximum is 364635d (999 years)",
+				),
+			},
+
+			"recoverability": {
+				Type:     schema.TypeBool,
+				Required: true,
+			},
+		},
+		CustomizeDiff: clusterConfigCustomDiff,
+	}
+}
+
+func resourceADXDatabaseRetentionPolicyCreateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	databaseName := d.Get("database_name").(string)
+	softDeleteTimespan := d.Get("soft_delete_period").(string)
+	recoverability := d.Get("recoverability").(bool)
+
+	recoverabilityString := "enabled"
+	if !recoverability {
+		recoverabilityString = "disabled"
+	}
+
+	softDelete, err := kql.Timespan(softDeleteTimespan)
+	if err != nil {
+		return diag.Errorf("%+v", err)
+	}
+
+	createStatement := kql.New(".alter-merge", "database").Identifier(databaseName).
+		Keyword("policy", "retention", "softdelete", "=").Literal(softDelete).Keyword("recoverability", "=", recoverabilityString).String()
+
+	if err := createADXPolicy(ctx, d, meta, "database", "retention", databaseName, databaseName, createStatement); err != nil {
+		return diag.Errorf("%+v", err)
+	}
+
+	return resourceADXDatabaseRetentionPolicyRead(ctx, d, meta)
+}
+
+func resourceADXDatabaseRetentionPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clusterConfig := getAndExpandClusterConfigWithDefaults(ctx, d, meta)
+
+	id, resultSet, diags := readADXPolicy(ctx, d, meta, "database", "retention")
+	if diags.HasError() || resultSet == nil || len(resultSet) == 0 {
+		return diags
+	}
+
+	if resultSet[0].Policy == "null" {
+		d.SetId("")
+		return diags
+	}
+
+	var policy DatabaseRetentionPolicy
+	if err := json.Unmarshal([]byte(resultSet[0].Policy), &policy); err != nil {
+		return diag.Errorf("error parsing policy retention for Database %q: %+v", id.DatabaseName, err)
+	}
+
+	originalSoftDeletePeriod := d.Get("soft_delete_period")
+
+	if originalSoftDeletePeriod != "" {
+		originalSoftDeletePeriodTimeUnit := originalSoftDeletePeriod.(string)[len(originalSoftDeletePeriod.(string))-1:]
+
+		softDeletePeriod, err := toADXTimespanLiteral(ctx, meta, clusterConfig, id.DatabaseName, policy.SoftDeletePeriod, originalSoftDeletePeriodTimeUnit)
+		if err != nil {
+			return diag.Errorf("%+v", err)
+		}
+		d.Set("soft_delete_period", softDeletePeriod)
+	} else {
+		d.Set("soft_delete_period", policy.SoftDeletePeriod)
+	}
+
+	d.Set("database_name", id.DatabaseName)
+	d.Set("recoverability", policy.Recoverability == "Enabled")
+
+	return diags
+}
+
+func resourceADXDatabaseRetentionPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return deleteADXPolicy(ctx, d, meta, "database", "retention")
+}
@@ -0,0 +1,42 @@
+package adx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXDatabaseRetentionPolicy_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db")
+	r := newFakeResource(t, f, resourceADXDatabaseRetentionPolicy())
+
+	state := r.apply(map[string]interface{}{
+		"database_name":      "fake_db",
+		"soft_delete_period": "365d",
+		"recoverability":     true,
+	})
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|database|fake_db|policy|retention", f.endpoint()), state.ID)
+	assert.Equal(t, "365d", state.Attributes["soft_delete_period"])
+	assert.Equal(t, "true", state.Attributes["recoverability"])
+
+	state = r.apply(map[string]interface{}{
+		"database_name":      "fake_db",
+		"soft_delete_period": "48h",
+		"recoverability":     false,
+	})
+	assert.Equal(t, "48h", state.Attributes["soft_delete_period"])
+	assert.Equal(t, "false", state.Attributes["recoverability"])
+
+	r.checkImport(f, "soft_delete_period")
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Empty(t, f.database("fake_db").policies)
+	assert.Equal(t, []string{
+		".alter-merge database fake_db policy retention softdelete = 365d recoverability = enabled",
+		".alter-merge database fake_db policy retention softdelete = 48h recoverability = disabled",
+		".delete database fake_db policy retention",
+	}, f.controlCommands())
+}
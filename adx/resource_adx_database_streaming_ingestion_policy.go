@@ -0,0 +1,117 @@
+package adx
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
+	"github.com/favoretti/terraform-provider-adx/adx/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type DatabaseStreamingIngestionPolicy struct {
+	IsEnabled         bool
+	HintAllocatedRate string
+}
+
+func resourceADXDatabaseStreamingIngestionPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceADXDatabaseStreamingIngestionPolicyCreateUpdate,
+		ReadContext:   resourceADXDatabaseStreamingIngestionPolicyRead,
+		DeleteContext: resourceADXDatabaseStreamingIngestionPolicyDelete,
+		UpdateContext: resourceADXDatabaseStreamingIngestionPolicyCreateUpdate,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster": getClusterConfigInputSchema(),
+			"database_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+			},
+
+			"enabled": {
+				Type:     schema.TypeBool,
+				Required: true,
+			},
+
+			"hint_allocated_rate": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Optional: true,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					oldFloat, err := strconv.ParseFloat(old, 64)
+					if err != nil {
+						return false
+					}
+					newFloat, err := strconv.ParseFloat(new, 64)
+					if err != nil {
+						return false
+					}
+					return oldFloat == newFloat
+				},
+			},
+		},
+		CustomizeDiff: clusterConfigCustomDiff,
+	}
+}
+
+func resourceADXDatabaseStreamingIngestionPolicyCreateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	databaseName := d.Get("database_name").(string)
+	enabled := d.Get("enabled").(bool)
+
+	// HintAllocatedRate is sent as null when it is not set
+	policy := struct {
+		IsEnabled         bool
+		HintAllocatedRate *string
+	}{IsEnabled: enabled}
+	if hintAllocatedRate, ok := d.GetOk("hint_allocated_rate"); ok {
+		hint := hintAllocatedRate.(string)
+		policy.HintAllocatedRate = &hint
+	}
+
+	policyJSON, err := kql.JSON(policy)
+	if err != nil {
+		return diag.Errorf("error serializing policy streamingingestion for Database %q: %+v", databaseName, err)
+	}
+
+	createStatement := kql.New(".alter", "database").Identifier(databaseName).Keyword("policy", "streamingingestion").Literal(policyJSON).String()
+
+	if err := createADXPolicy(ctx, d, meta, "database", "streamingingestion", databaseName, databaseName, createStatement); err != nil {
+		return diag.Errorf("%+v", err)
+	}
+
+	return resourceADXDatabaseStreamingIngestionPolicyRead(ctx, d, meta)
+}
+
+func resourceADXDatabaseStreamingIngestionPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id, resultSet, diags := readADXPolicy(ctx, d, meta, "database", "streamingingestion")
+	if diags.HasError() || resultSet == nil || len(resultSet) == 0 {
+		return diags
+	}
+
+	if resultSet[0].Policy == "null" {
+		d.SetId("")
+		return diags
+	}
+
+	var policy DatabaseStreamingIngestionPolicy
+	if err := json.Unmarshal([]byte(resultSet[0].Policy), &policy); err != nil {
+		return diag.Errorf("error parsing policy streamingingestion for Database %q: %+v", id.DatabaseName, err)
+	}
+
+	d.Set("database_name", id.DatabaseName)
+	d.Set("enabled", policy.IsEnabled)
+	d.Set("hint_allocated_rate", policy.HintAllocatedRate)
+
+	return diags
+}
+
+func resourceADXDatabaseStreamingIngestionPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return deleteADXPolicy(ctx, d, meta, "database", "streamingingestion")
+}
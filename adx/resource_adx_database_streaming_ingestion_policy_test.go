@@ -0,0 +1,34 @@
+package adx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXDatabaseStreamingIngestionPolicy_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db")
+	r := newFakeResource(t, f, resourceADXDatabaseStreamingIngestionPolicy())
+
+	state := r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"enabled":       true,
+	})
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|database|fake_db|policy|streamingingestion", f.endpoint()), state.ID)
+	assert.Equal(t, "true", state.Attributes["enabled"])
+
+	state = r.apply(map[string]interface{}{
+		"database_name":       "fake_db",
+		"enabled":             true,
+		"hint_allocated_rate": "2.5",
+	})
+	assert.Equal(t, "2.5", state.Attributes["hint_allocated_rate"])
+
+	r.checkImport(f)
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Empty(t, f.database("fake_db").policies)
+}
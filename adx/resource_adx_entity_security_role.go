@@ -0,0 +1,285 @@
+package adx
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
+	"github.com/favoretti/terraform-provider-adx/adx/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// entitySecurityRoleKeywords are the command keywords of the entities that can hold security roles,
+// keyed by entity_type
+var entitySecurityRoleKeywords = map[string][]string{
+	"table":             {"table"},
+	"function":          {"function"},
+	"materialized_view": {"materialized-view"},
+	"external_table":    {"external", "table"},
+}
+
+func entitySecurityRoleTypes() []string {
+	var types []string
+	for entityType := range entitySecurityRoleKeywords {
+		types = append(types, entityType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func resourceADXEntitySecurityRole() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceADXEntitySecurityRoleCreate,
+		ReadContext:   resourceADXEntitySecurityRoleRead,
+		UpdateContext: resourceADXEntitySecurityRoleUpdate,
+		DeleteContext: resourceADXEntitySecurityRoleDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster": getClusterConfigInputSchema(),
+			"database_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+			},
+
+			"entity_type": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringInSlice(entitySecurityRoleTypes()),
+			},
+
+			"entity_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+			},
+
+			"role": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+			},
+
+			"principal_fqn": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+				DiffSuppressFunc: suppressPrincipalFQNDiff,
+				Description:      "Fully qualified name of the principal, e.g. 'aaduser=user@example.com' or 'aadapp=<app-id>;<tenant>'",
+			},
+
+			"notes": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Free text notes describing the role assignment",
+			},
+
+			"principal_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Type of the principal (e.g., AAD User, AAD App, AAD Group)",
+			},
+
+			"principal_display_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Display name of the principal",
+			},
+		},
+		CustomizeDiff: entitySecurityRoleCustomizeDiff,
+	}
+}
+
+func entitySecurityRoleCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if err := clusterConfigCustomDiff(ctx, diff, meta); err != nil {
+		return err
+	}
+	return validateSecurityRole(diff.Get("entity_type").(string), diff.Get("role").(string))
+}
+
+func resourceADXEntitySecurityRoleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := createEntitySecurityRole(ctx, d, meta, d.Get("entity_type").(string), d.Get("entity_name").(string)); diags != nil {
+		return diags
+	}
+	return resourceADXEntitySecurityRoleRead(ctx, d, meta)
+}
+
+func resourceADXEntitySecurityRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id, found, diags := readEntitySecurityRole(ctx, d, meta)
+	if diags.HasError() || found == nil {
+		return diags
+	}
+
+	d.Set("database_name", id.DatabaseName)
+	d.Set("entity_type", id.EntityType)
+	d.Set("entity_name", id.Name)
+	d.Set("role", id.Role)
+	d.Set("principal_fqn", found.PrincipalFQN)
+	d.Set("principal_type", found.PrincipalType)
+	d.Set("principal_display_name", found.PrincipalDisplayName)
+	d.Set("notes", found.Notes)
+
+	return diags
+}
+
+func resourceADXEntitySecurityRoleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := updateEntitySecurityRole(ctx, d, meta); diags != nil {
+		return diags
+	}
+	return resourceADXEntitySecurityRoleRead(ctx, d, meta)
+}
+
+func resourceADXEntitySecurityRoleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return deleteEntitySecurityRole(ctx, d, meta)
+}
+
+// createEntitySecurityRole adds the principal to the role and sets the resource id from the FQN resolved by ADX
+func createEntitySecurityRole(ctx context.Context, d *schema.ResourceData, meta interface{}, entityType string, entityName string) diag.Diagnostics {
+	clusterConfig := getAndExpandClusterConfigWithDefaults(ctx, d, meta)
+
+	databaseName := d.Get("database_name").(string)
+	role := d.Get("role").(string)
+	principalFQN := d.Get("principal_fqn").(string)
+	notes := d.Get("notes").(string)
+
+	client, err := getADXClient(meta, clusterConfig)
+	if err != nil {
+		return diag.Errorf("error creating adx client connection: %+v", err)
+	}
+
+	addStatement := buildEntitySecurityRoleStatement(".add", entityType, entityName, role, principalFQN, notes)
+
+	// The .add command returns the updated list of principals — parse it to get
+	// the actual (resolved) FQN that ADX stores for this principal.
+	resultSet, err := queryADXMgmtAndParse[TablePrincipal](ctx, meta, clusterConfig, databaseName, addStatement)
+	if err != nil {
+		return diag.Errorf("error adding %s role for principal %q on %s %q (Database %q): %+v", role, principalFQN, entityType, entityName, databaseName, err)
+	}
+
+	actualFQN := resolvedPrincipalFQN(resultSet, role, principalFQN)
+
+	d.SetId(buildADXResourceId(client.Endpoint(), databaseName, entityType, entityName, "security_role", role, actualFQN))
+	return nil
+}
+
+// readEntitySecurityRole looks up the principal of the resource, the id is cleared and a nil principal
+// returned when the entity or the role assignment no longer exists
+func readEntitySecurityRole(ctx context.Context, d *schema.ResourceData, meta interface{}) (*adxEntitySecurityRoleResourceId, *TablePrincipal, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	clusterConfig := getAndExpandClusterConfigWithDefaults(ctx, d, meta)
+
+	id, err := parseADXEntitySecurityRoleID(d.Id())
+	if err != nil {
+		return nil, nil, diag.Errorf("error parsing resource ID: %+v", err)
+	}
+
+	kustoEntityType := strings.Join(entitySecurityRoleKeywords[id.EntityType], " ")
+	if entityExists, err := isEntityExists(ctx, meta, clusterConfig, id.DatabaseName, kustoEntityType, id.Name); err != nil || !entityExists {
+		if err != nil {
+			return nil, nil, diag.Errorf("%+v", err)
+		}
+		d.SetId("")
+		return id, nil, diags
+	}
+
+	showStatement := kql.New(".show").Keyword(entitySecurityRoleKeywords[id.EntityType]...).Identifier(id.Name).Keyword("principals").String()
+
+	resultSet, err := queryADXMgmtAndParse[TablePrincipal](ctx, meta, clusterConfig, id.DatabaseName, showStatement)
+	if err != nil {
+		return nil, nil, diag.Errorf("error reading principals for %s %q (Database %q): %+v", id.EntityType, id.Name, id.DatabaseName, err)
+	}
+
+	found := findPrincipal(resultSet, id.Role, id.PrincipalFQN)
+	if found == nil {
+		d.SetId("")
+	}
+	return id, found, diags
+}
+
+// updateEntitySecurityRole replaces the notes of a role assignment, which requires adding it again
+func updateEntitySecurityRole(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clusterConfig := getAndExpandClusterConfigWithDefaults(ctx, d, meta)
+
+	id, err := parseADXEntitySecurityRoleID(d.Id())
+	if err != nil {
+		return diag.Errorf("error parsing resource ID: %+v", err)
+	}
+
+	notes := d.Get("notes").(string)
+
+	dropStatement := buildEntitySecurityRoleStatement(".drop", id.EntityType, id.Name, id.Role, id.PrincipalFQN, "")
+	_, err = queryADXMgmt(ctx, meta, clusterConfig, id.DatabaseName, dropStatement)
+	if err != nil {
+		return diag.Errorf("error dropping %s role for principal %q on %s %q (Database %q) during update: %+v", id.Role, id.PrincipalFQN, id.EntityType, id.Name, id.DatabaseName, err)
+	}
+
+	addStatement := buildEntitySecurityRoleStatement(".add", id.EntityType, id.Name, id.Role, id.PrincipalFQN, notes)
+
+	_, err = queryADXMgmt(ctx, meta, clusterConfig, id.DatabaseName, addStatement)
+	if err != nil {
+		return diag.Errorf("error re-adding %s role for principal %q on %s %q (Database %q) during update: %+v", id.Role, id.PrincipalFQN, id.EntityType, id.Name, id.DatabaseName, err)
+	}
+
+	return nil
+}
+
+func deleteEntitySecurityRole(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clusterConfig := getAndExpandClusterConfigWithDefaults(ctx, d, meta)
+
+	id, err := parseADXEntitySecurityRoleID(d.Id())
+	if err != nil {
+		return diag.Errorf("error parsing resource ID: %+v", err)
+	}
+
+	dropStatement := buildEntitySecurityRoleStatement(".drop", id.EntityType, id.Name, id.Role, id.PrincipalFQN, "")
+
+	return deleteADXEntity(ctx, d, meta, clusterConfig, id.DatabaseName, dropStatement)
+}
+
+// buildEntitySecurityRoleStatement builds the .add or .drop command for a single principal of an entity
+func buildEntitySecurityRoleStatement(cmd string, entityType string, entityName string, role string, principalFQN string, notes string) string {
+	statement := kql.New(cmd).Keyword(entitySecurityRoleKeywords[entityType]...).Identifier(entityName).Keyword(role).StringList(principalFQN)
+	if notes != "" {
+		statement.StringLiteral(notes)
+	}
+	return statement.String()
+}
+
+type adxEntitySecurityRoleResourceId struct {
+	adxResourceId
+	Role         string
+	PrincipalFQN string
+}
+
+func parseADXEntitySecurityRoleID(input string) (*adxEntitySecurityRoleResourceId, error) {
+	parts := strings.Split(input, "|")
+	if len(parts) != 7 || parts[4] != "security_role" {
+		return nil, fmt.Errorf("error parsing ADX Security Role resource ID: unexpected format: %q, expected <cluster>|<database>|<entity_type>|<entity_name>|security_role|<role>|<principal_fqn>", input)
+	}
+	if _, ok := entitySecurityRoleKeywords[parts[2]]; !ok {
+		return nil, fmt.Errorf("error parsing ADX Security Role resource ID: unsupported entity type %q in %q", parts[2], input)
+	}
+
+	return &adxEntitySecurityRoleResourceId{
+		adxResourceId: adxResourceId{
+			EndpointURI:  parts[0],
+			DatabaseName: parts[1],
+			EntityType:   parts[2],
+			Name:         parts[3],
+		},
+		Role:         parts[5],
+		PrincipalFQN: parts[6],
+	}, nil
+}
@@ -0,0 +1,86 @@
+package adx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXEntitySecurityRole_function(t *testing.T) {
+	f := newFakeKusto(t)
+	fn := f.database("fake_db").addFunction("fake_function", "()", "{ print 1 }")
+	r := newFakeResource(t, f, resourceADXEntitySecurityRole())
+
+	config := map[string]interface{}{
+		"database_name": "fake_db",
+		"entity_type":   "function",
+		"entity_name":   "fake_function",
+		"role":          "admins",
+		"principal_fqn": "aaduser=owner@example.com",
+		"notes":         "function owner",
+	}
+	state := r.apply(config)
+	resolvedFQN := resolvePrincipalFQN("aaduser=owner@example.com")
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|function|fake_function|security_role|admins|%s", f.endpoint(), resolvedFQN), state.ID)
+	assert.Equal(t, "AAD User", state.Attributes["principal_type"])
+
+	config["notes"] = "updated notes"
+	state = r.apply(config)
+	assert.Equal(t, "updated notes", state.Attributes["notes"])
+
+	r.checkImport(f)
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Empty(t, fn.principals)
+	assert.Equal(t, []string{
+		".add function fake_function admins ('aaduser=owner@example.com') 'function owner'",
+		fmt.Sprintf(".drop function fake_function admins ('%s')", resolvedFQN),
+		fmt.Sprintf(".add function fake_function admins ('%s') 'updated notes'", resolvedFQN),
+		fmt.Sprintf(".drop function fake_function admins ('%s')", resolvedFQN),
+	}, f.controlCommands())
+}
+
+func TestFakeADXEntitySecurityRole_entityTypes(t *testing.T) {
+	f := newFakeKusto(t)
+	db := f.database("fake_db")
+	db.addTable("fake_table", "f1:string")
+	db.addMaterializedView("fake_view", "fake_table", "fake_table | summarize count() by f1")
+	db.addExternalTable("fake_external", "f1:string")
+	app := "aadapp=4c7e82bd-6adb-46c3-b413-fdd44834c69b;" + fakeKustoTenantID
+
+	for _, entity := range []struct{ entityType, entityName string }{
+		{"materialized_view", "fake_view"},
+		{"external_table", "fake_external"},
+		{"table", "fake_table"},
+	} {
+		r := newFakeResource(t, f, resourceADXEntitySecurityRole())
+		state := r.apply(map[string]interface{}{
+			"database_name": "fake_db",
+			"entity_type":   entity.entityType,
+			"entity_name":   entity.entityName,
+			"role":          "admins",
+			"principal_fqn": app,
+		})
+		assert.Equal(t, fmt.Sprintf("%s|fake_db|%s|%s|security_role|admins|%s", f.endpoint(), entity.entityType, entity.entityName, app), state.ID)
+		r.checkImport(f)
+		r.destroy()
+		r.checkDestroyed()
+	}
+
+	assert.Equal(t, []string{
+		fmt.Sprintf(".add materialized-view fake_view admins ('%s')", app),
+		fmt.Sprintf(".drop materialized-view fake_view admins ('%s')", app),
+		fmt.Sprintf(".add external table fake_external admins ('%s')", app),
+		fmt.Sprintf(".drop external table fake_external admins ('%s')", app),
+		fmt.Sprintf(".add table fake_table admins ('%s')", app),
+		fmt.Sprintf(".drop table fake_table admins ('%s')", app),
+	}, f.controlCommands())
+}
+
+func TestUtils_validateSecurityRole_entityTypes(t *testing.T) {
+	assert.NoError(t, validateSecurityRole("function", "admins"))
+	assert.ErrorContains(t, validateSecurityRole("function", "ingestors"), "expected one of: admins")
+	assert.ErrorContains(t, validateSecurityRole("materialized_view", "viewers"), "expected one of: admins")
+}
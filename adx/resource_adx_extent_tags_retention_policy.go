@@ -0,0 +1,246 @@
+package adx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
+	"github.com/favoretti/terraform-provider-adx/adx/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type ExtentTagsRetentionRule struct {
+	TagPrefix       string `json:"TagPrefix"`
+	RetentionPeriod string `json:"RetentionPeriod"`
+}
+
+var (
+	extentTagsRetentionPeriodPattern = regexp.MustCompile(`^([0-9]{1,5})([dhms])$`)
+	adxTimespanPattern               = regexp.MustCompile(`^(?:([0-9]+)\.)?([0-9]{2}):([0-9]{2}):([0-9]{2})$`)
+)
+
+func resourceADXExtentTagsRetentionPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceADXExtentTagsRetentionPolicyCreateUpdate,
+		ReadContext:   resourceADXExtentTagsRetentionPolicyRead,
+		UpdateContext: resourceADXExtentTagsRetentionPolicyCreateUpdate,
+		DeleteContext: resourceADXExtentTagsRetentionPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster": getClusterConfigInputSchema(),
+			"database_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+			},
+
+			"entity_type": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringInSlice([]string{"database", "table"}),
+			},
+
+			"entity_name": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+			},
+
+			"rule": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tag_prefix": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validate.StringIsNotEmpty,
+							Description:      "Prefix of the extent tags the rule applies to, e.g. 'drop-by:'",
+						},
+						"retention_period": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateDiagFunc: validate.StringMatch(
+								extentTagsRetentionPeriodPattern,
+								"retention_period must be in the format of <amount><unit> such as 12h (twelve hours) or 3d (three days)",
+							),
+							Description: "Time the matching tags are kept after the extent was created",
+						},
+					},
+				},
+			},
+		},
+		CustomizeDiff: extentTagsRetentionPolicyCustomizeDiff,
+	}
+}
+
+func extentTagsRetentionPolicyCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if err := clusterConfigCustomDiff(ctx, diff, meta); err != nil {
+		return err
+	}
+
+	if diff.Get("entity_type").(string) == "table" {
+		entityName, ok := diff.GetOk("entity_name")
+		if !ok || entityName.(string) == "" {
+			return fmt.Errorf("entity_name is required when entity_type is \"table\"")
+		}
+	}
+	return nil
+}
+
+func resourceADXExtentTagsRetentionPolicyCreateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	databaseName := d.Get("database_name").(string)
+	entityType := d.Get("entity_type").(string)
+
+	entityName := databaseName
+	if entityType != "database" {
+		entityName = d.Get("entity_name").(string)
+	}
+
+	rules := make([]ExtentTagsRetentionRule, 0)
+	for _, v := range d.Get("rule").([]interface{}) {
+		rule := v.(map[string]interface{})
+		retentionPeriod, err := toADXPolicyTimespan(rule["retention_period"].(string))
+		if err != nil {
+			return diag.Errorf("%+v", err)
+		}
+		rules = append(rules, ExtentTagsRetentionRule{
+			TagPrefix:       rule["tag_prefix"].(string),
+			RetentionPeriod: retentionPeriod,
+		})
+	}
+
+	policyJSON, err := kql.JSON(rules)
+	if err != nil {
+		return diag.Errorf("error serializing extent tags retention policy: %+v", err)
+	}
+
+	createStatement := kql.New(".alter", entityType).Identifier(entityName).Keyword("policy", "extent_tags_retention").Literal(policyJSON).String()
+
+	if diags := createADXPolicy(ctx, d, meta, entityType, "extent_tags_retention", databaseName, entityName, createStatement); diags != nil {
+		return diags
+	}
+
+	return resourceADXExtentTagsRetentionPolicyRead(ctx, d, meta)
+}
+
+func resourceADXExtentTagsRetentionPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clusterConfig := getAndExpandClusterConfigWithDefaults(ctx, d, meta)
+
+	id, err := parseADXPolicyID(d.Id())
+	if err != nil {
+		return diag.Errorf("error parsing extent tags retention policy ID: %+v", err)
+	}
+
+	_, resultSet, diags := readADXPolicy(ctx, d, meta, id.EntityType, "extent_tags_retention")
+	if diags.HasError() || resultSet == nil || len(resultSet) == 0 {
+		return diags
+	}
+
+	if resultSet[0].Policy == "null" || resultSet[0].Policy == "" {
+		d.SetId("")
+		return diags
+	}
+
+	var policy []ExtentTagsRetentionRule
+	if err := json.Unmarshal([]byte(resultSet[0].Policy), &policy); err != nil {
+		return diag.Errorf("error parsing extent tags retention policy for %s %q (Database %q): %+v", id.EntityType, id.Name, id.DatabaseName, err)
+	}
+
+	// keep the unit of the configured period, as done for soft_delete_period
+	configuredUnits := make(map[string]string)
+	for _, v := range d.Get("rule").([]interface{}) {
+		if v == nil {
+			continue
+		}
+		rule := v.(map[string]interface{})
+		if m := extentTagsRetentionPeriodPattern.FindStringSubmatch(rule["retention_period"].(string)); m != nil {
+			configuredUnits[rule["tag_prefix"].(string)] = m[2]
+		}
+	}
+
+	rules := make([]interface{}, 0, len(policy))
+	for _, rule := range policy {
+		unit, ok := configuredUnits[rule.TagPrefix]
+		if !ok {
+			// imported or added outside of terraform, pick the unit the period is expressed in best
+			unit = largestADXTimespanUnit(rule.RetentionPeriod)
+		}
+		retentionPeriod, err := toADXTimespanLiteral(ctx, meta, clusterConfig, id.DatabaseName, rule.RetentionPeriod, unit)
+		if err != nil {
+			return diag.Errorf("%+v", err)
+		}
+		rules = append(rules, map[string]interface{}{
+			"tag_prefix":       rule.TagPrefix,
+			"retention_period": retentionPeriod,
+		})
+	}
+
+	d.Set("database_name", id.DatabaseName)
+	d.Set("entity_type", id.EntityType)
+	if id.EntityType != "database" {
+		d.Set("entity_name", id.Name)
+	}
+	d.Set("rule", rules)
+
+	return diags
+}
+
+func resourceADXExtentTagsRetentionPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id, err := parseADXPolicyID(d.Id())
+	if err != nil {
+		return diag.Errorf("error parsing extent tags retention policy ID: %+v", err)
+	}
+
+	return deleteADXPolicy(ctx, d, meta, id.EntityType, "extent_tags_retention")
+}
+
+// toADXPolicyTimespan converts a period such as 3d into the d.hh:mm:ss form timespans take in policy JSON
+func toADXPolicyTimespan(period string) (string, error) {
+	m := extentTagsRetentionPeriodPattern.FindStringSubmatch(period)
+	if m == nil {
+		return "", fmt.Errorf("invalid period %q, expected <amount><unit> such as 3d", period)
+	}
+	amount, _ := strconv.Atoi(m[1])
+	units := map[string]time.Duration{"d": 24 * time.Hour, "h": time.Hour, "m": time.Minute, "s": time.Second}
+	value := time.Duration(amount) * units[m[2]]
+
+	days := int(value / (24 * time.Hour))
+	value -= time.Duration(days) * 24 * time.Hour
+	return fmt.Sprintf("%d.%02d:%02d:%02d", days, int(value/time.Hour), int(value%time.Hour/time.Minute), int(value%time.Minute/time.Second)), nil
+}
+
+// largestADXTimespanUnit returns the largest of d, h, m and s that divides a d.hh:mm:ss timespan exactly
+func largestADXTimespanUnit(timespan string) string {
+	m := adxTimespanPattern.FindStringSubmatch(timespan)
+	if m == nil {
+		return ""
+	}
+	days, _ := strconv.Atoi(m[1])
+	hours, _ := strconv.Atoi(m[2])
+	minutes, _ := strconv.Atoi(m[3])
+	seconds, _ := strconv.Atoi(m[4])
+	value := time.Duration(days)*24*time.Hour + time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+
+	for _, unit := range []struct {
+		name     string
+		duration time.Duration
+	}{{"d", 24 * time.Hour}, {"h", time.Hour}, {"m", time.Minute}} {
+		if value%unit.duration == 0 {
+			return unit.name
+		}
+	}
+	return "s"
+}
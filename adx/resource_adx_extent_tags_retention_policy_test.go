@@ -0,0 +1,119 @@
+package adx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXExtentTagsRetentionPolicy_table(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db").addTable("fake_table", "f1:string")
+	r := newFakeResource(t, f, resourceADXExtentTagsRetentionPolicy())
+
+	config := map[string]interface{}{
+		"database_name": "fake_db",
+		"entity_type":   "table",
+		"entity_name":   "fake_table",
+		"rule": []interface{}{
+			map[string]interface{}{"tag_prefix": "drop-by:", "retention_period": "3d"},
+			map[string]interface{}{"tag_prefix": "ingest-by:", "retention_period": "36h"},
+		},
+	}
+	state := r.apply(config)
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|table|fake_table|policy|extent_tags_retention", f.endpoint()), state.ID)
+	assert.Equal(t, "3d", state.Attributes["rule.0.retention_period"])
+	assert.Equal(t, "36h", state.Attributes["rule.1.retention_period"])
+	assert.JSONEq(t, `[{"TagPrefix":"drop-by:","RetentionPeriod":"3.00:00:00"},{"TagPrefix":"ingest-by:","RetentionPeriod":"1.12:00:00"}]`, f.database("fake_db").policies["table|fake_table|extent_tags_retention"])
+
+	config["rule"] = []interface{}{
+		map[string]interface{}{"tag_prefix": "drop-by:", "retention_period": "12h"},
+	}
+	state = r.apply(config)
+	assert.Equal(t, "1", state.Attributes["rule.#"])
+	assert.Equal(t, "12h", state.Attributes["rule.0.retention_period"])
+
+	r.checkImport(f)
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Empty(t, f.database("fake_db").policies)
+}
+
+func TestFakeADXExtentTagsRetentionPolicy_database(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db")
+	r := newFakeResource(t, f, resourceADXExtentTagsRetentionPolicy())
+
+	state := r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"entity_type":   "database",
+		"rule": []interface{}{
+			map[string]interface{}{"tag_prefix": "drop-by:", "retention_period": "7d"},
+		},
+	})
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|database|fake_db|policy|extent_tags_retention", f.endpoint()), state.ID)
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Equal(t, []string{
+		`.alter database fake_db policy extent_tags_retention '[{"TagPrefix":"drop-by:","RetentionPeriod":"7.00:00:00"}]'`,
+		".delete database fake_db policy extent_tags_retention",
+	}, f.controlCommands())
+}
+
+func TestFakeADXExtentTagsRetentionPolicy_reorderRules(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db")
+	r := newFakeResource(t, f, resourceADXExtentTagsRetentionPolicy())
+
+	config := map[string]interface{}{
+		"database_name": "fake_db",
+		"entity_type":   "database",
+		"rule": []interface{}{
+			map[string]interface{}{"tag_prefix": "drop-by:", "retention_period": "72h"},
+			map[string]interface{}{"tag_prefix": "ingest-by:", "retention_period": "2d"},
+		},
+	}
+	r.apply(config)
+
+	// the units follow the tag prefix, not the position of the rule
+	f.database("fake_db").policies["database|fake_db|extent_tags_retention"] = `[{"TagPrefix":"ingest-by:","RetentionPeriod":"2.00:00:00"},{"TagPrefix":"drop-by:","RetentionPeriod":"3.00:00:00"},{"TagPrefix":"other:","RetentionPeriod":"0.01:30:00"}]`
+	state := r.refresh()
+	assert.Equal(t, "ingest-by:", state.Attributes["rule.0.tag_prefix"])
+	assert.Equal(t, "2d", state.Attributes["rule.0.retention_period"])
+	assert.Equal(t, "drop-by:", state.Attributes["rule.1.tag_prefix"])
+	assert.Equal(t, "72h", state.Attributes["rule.1.retention_period"])
+	assert.Equal(t, "other:", state.Attributes["rule.2.tag_prefix"])
+	assert.Equal(t, "90m", state.Attributes["rule.2.retention_period"])
+}
+
+func TestADXExtentTagsRetentionPolicy_largestADXTimespanUnit(t *testing.T) {
+	for timespan, expected := range map[string]string{
+		"3.00:00:00": "d",
+		"1.12:00:00": "h",
+		"12:00:00":   "h",
+		"0.01:30:00": "m",
+		"0.00:00:45": "s",
+		"3 days":     "",
+	} {
+		assert.Equal(t, expected, largestADXTimespanUnit(timespan), timespan)
+	}
+}
+
+func TestADXExtentTagsRetentionPolicy_toADXPolicyTimespan(t *testing.T) {
+	for period, expected := range map[string]string{
+		"3d":   "3.00:00:00",
+		"36h":  "1.12:00:00",
+		"90m":  "0.01:30:00",
+		"45s":  "0.00:00:45",
+		"400d": "400.00:00:00",
+	} {
+		actual, err := toADXPolicyTimespan(period)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual, period)
+	}
+	_, err := toADXPolicyTimespan("3 days")
+	assert.Error(t, err)
+}
@@ -2,10 +2,9 @@ package adx
 
 import (
 	"context"
-	"fmt"
-	"strings"
 	"time"
 
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
 	"github.com/favoretti/terraform-provider-adx/adx/validate"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -157,59 +156,52 @@ func resourceADXExternalTableCreateUpdate(ctx context.Context, d *schema.Resourc
 	pathFormat := d.Get("path_format").(string)
 	kind := d.Get("kind").(string)
 
-	var withParams []string
+	withParams := kql.NewProperties()
 
 	if folder, ok := d.GetOk("folder"); ok {
-		withParams = append(withParams, fmt.Sprintf("folder=%s", folder.(string)))
+		withParams.String("folder", folder.(string))
 	}
 	if docString, ok := d.GetOk("doc_string"); ok {
-		withParams = append(withParams, fmt.Sprintf("docString=%s", docString.(string)))
+		withParams.String("docString", docString.(string))
 	}
 	if compressed, ok := d.GetOk("compressed"); ok {
-		withParams = append(withParams, fmt.Sprintf("compressed=%t", compressed.(bool)))
+		withParams.Bool("compressed", compressed.(bool))
 	}
 	if includeHeaders, ok := d.GetOk("include_headers"); ok {
-		withParams = append(withParams, fmt.Sprintf("includeHeaders=%s", includeHeaders.(string)))
+		withParams.String("includeHeaders", includeHeaders.(string))
 	}
 	if namePrefix, ok := d.GetOk("name_prefix"); ok {
-		withParams = append(withParams, fmt.Sprintf("namePrefix=%s", namePrefix.(string)))
+		withParams.String("namePrefix", namePrefix.(string))
 	}
 	if fileExtension, ok := d.GetOk("file_extension"); ok {
-		withParams = append(withParams, fmt.Sprintf("fileExtension='%s'", fileExtension.(string)))
+		withParams.String("fileExtension", fileExtension.(string))
 	}
 	if encoding, ok := d.GetOk("encoding"); ok {
-		withParams = append(withParams, fmt.Sprintf("encoding='%s'", encoding.(string)))
+		withParams.String("encoding", encoding.(string))
 	}
 	if sampleUris, ok := d.GetOk("sample_uris"); ok {
-		withParams = append(withParams, fmt.Sprintf("sampleUris=%t", sampleUris.(bool)))
+		withParams.Bool("sampleUris", sampleUris.(bool))
 	}
 	if filesPreview, ok := d.GetOk("files_preview"); ok {
-		withParams = append(withParams, fmt.Sprintf("filesPreview=%t", filesPreview.(bool)))
+		withParams.Bool("filesPreview", filesPreview.(bool))
 	}
 	if validateNotEmpty, ok := d.GetOk("validate_not_empty"); ok {
-		withParams = append(withParams, fmt.Sprintf("validateNotEmpty=%t", validateNotEmpty.(bool)))
+		withParams.Bool("validateNotEmpty", validateNotEmpty.(bool))
 	}
 	if dryRun, ok := d.GetOk("dry_run"); ok {
-		withParams = append(withParams, fmt.Sprintf("dryRun=%t", dryRun.(bool)))
+		withParams.Bool("dryRun", dryRun.(bool))
 	}
 
-	withClause := ""
-	if len(withParams) > 0 {
-		withClause = fmt.Sprintf("with(%s)", strings.Join(withParams, ", "))
+	createStatement := kql.New(".create-or-alter", "external", "table").Identifier(name).Literal("("+schema+")").Keyword("kind", "=", kind)
+	if len(partitions) > 0 {
+		createStatement.Keyword("partition", "by").Query("(" + partitions + ")")
 	}
-
-	createStatement := ""
-	if len(partitions) > 0 && len(pathFormat) > 0 {
-		createStatement = fmt.Sprintf(".create-or-alter external table %s (%s) kind = %s partition by (%s) pathformat = (%s) dataformat = %s ('%s') %s", name, schema, kind, partitions, pathFormat, dataFormat, storageConnectionString, withClause)
-	} else if len(partitions) > 0 && len(pathFormat) == 0 {
-		createStatement = fmt.Sprintf(".create-or-alter external table %s (%s) kind = %s partition by (%s) dataformat = %s ('%s') %s", name, schema, kind, partitions, dataFormat, storageConnectionString, withClause)
-	} else if len(partitions) == 0 && len(pathFormat) > 0 {
-		createStatement = fmt.Sprintf(".create-or-alter external table %s (%s) kind = %s pathformat = (%s) dataformat = %s ('%s') %s", name, schema, kind, pathFormat, dataFormat, storageConnectionString, withClause)
-	} else {
-		createStatement = fmt.Sprintf(".create-or-alter external table %s (%s) kind = %s dataformat = %s ('%s') %s", name, schema, kind, dataFormat, storageConnectionString, withClause)
+	if len(pathFormat) > 0 {
+		createStatement.Keyword("pathformat", "=").Query("(" + pathFormat + ")")
 	}
+	createStatement.Keyword("dataformat", "=", dataFormat).StringList(storageConnectionString).With(withParams)
 
-	_, err := queryADXMgmt(ctx, meta, clusterConfig, databaseName, createStatement)
+	_, err := queryADXMgmt(ctx, meta, clusterConfig, databaseName, createStatement.String())
 	if err != nil {
 		return diag.Errorf("error creating external table %s (Database %q): %+v", name, databaseName, err)
 	}
@@ -231,7 +223,7 @@ func resourceADXExternalTableRead(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
-	showCommand := fmt.Sprintf(".show external table %s ", id.Name)
+	showCommand := kql.New(".show", "external", "table").Identifier(id.Name).String()
 
 	resultSet, diags := readADXEntity[ADXExternalTable](ctx, meta, clusterConfig, id, showCommand, "external table")
 	if diags.HasError() {
@@ -263,7 +255,7 @@ func resourceADXExternalTableDelete(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	return deleteADXEntity(ctx, d, meta, clusterConfig, id.DatabaseName, fmt.Sprintf(".drop external table %s", id.Name))
+	return deleteADXEntity(ctx, d, meta, clusterConfig, id.DatabaseName, kql.New(".drop", "external", "table").Identifier(id.Name).String())
 }
 
 func parseADXExternalTableID(input string) (*adxResourceId, error) {
@@ -0,0 +1,42 @@
+package adx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXExternalTable_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	r := newFakeResource(t, f, resourceADXExternalTable())
+
+	config := map[string]interface{}{
+		"name":                      "fake_external",
+		"database_name":             "fake_db",
+		"schema":                    "Timestamp:datetime,f1:string",
+		"data_format":               "csv",
+		"storage_connection_string": "https://fake.blob.core.windows.net/container;fakekey",
+		"partitions":                "Date:datetime = bin(Timestamp, 1d)",
+		"path_format":               "datetime_pattern(\"yyyy/MM/dd\", Date)",
+		"folder":                    "fake",
+	}
+	state := r.apply(config)
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|externaltable|fake_external", f.endpoint()), state.ID)
+	assert.Equal(t, "Date:datetime = bin(Timestamp, 1d)", state.Attributes["partitions"])
+	assert.Equal(t, "datetime_pattern(\"yyyy/MM/dd\", Date)", state.Attributes["path_format"])
+
+	config["folder"] = "fake/updated"
+	state = r.apply(config)
+	assert.Equal(t, "fake/updated", state.Attributes["folder"])
+
+	r.checkImport(f, "schema", "data_format", "kind", "include_headers", "encoding")
+
+	r.destroy()
+	assert.Empty(t, f.database("fake_db").externalTables)
+	assert.Equal(t, []string{
+		".create-or-alter external table fake_external (Timestamp:datetime,f1:string) kind = storage partition by (Date:datetime = bin(Timestamp, 1d)) pathformat = (datetime_pattern(\"yyyy/MM/dd\", Date)) dataformat = csv ('https://fake.blob.core.windows.net/container;fakekey') with(folder='fake', includeHeaders='All', encoding='UTF8NoBOM')",
+		".create-or-alter external table fake_external (Timestamp:datetime,f1:string) kind = storage partition by (Date:datetime = bin(Timestamp, 1d)) pathformat = (datetime_pattern(\"yyyy/MM/dd\", Date)) dataformat = csv ('https://fake.blob.core.windows.net/container;fakekey') with(folder='fake/updated', includeHeaders='All', encoding='UTF8NoBOM')",
+		".drop external table fake_external",
+	}, f.controlCommands())
+}
@@ -2,13 +2,9 @@ package adx
 
 import (
 	"context"
-	"fmt"
 	"regexp"
-	"strings"
-	"strconv"
 
-	"github.com/Azure/azure-kusto-go/kusto"
-	"github.com/Azure/azure-kusto-go/kusto/unsafe"
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
 	"github.com/favoretti/terraform-provider-adx/adx/validate"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -107,32 +103,27 @@ func resourceADXFunctionCreateUpdate(ctx context.Context, d *schema.ResourceData
 		cmd = ".create"
 	}
 
-	var withParams []string
+	withParams := kql.NewProperties()
 
 	if docstring, ok := d.GetOk("docstring"); ok {
-		withParams = append(withParams, fmt.Sprintf("docstring='%s'", docstring))
+		withParams.String("docstring", docstring.(string))
 	}
 	if folder, ok := d.GetOk("folder"); ok {
-		withParams = append(withParams, fmt.Sprintf("folder='%s'", folder))
+		withParams.String("folder", folder.(string))
 	}
 	if skip_validation, ok := d.Get("skip_validation").(bool); ok {
-		withParams = append(withParams, fmt.Sprintf("skipvalidation=%s", strconv.FormatBool(skip_validation)))
+		withParams.Bool("skipvalidation", skip_validation)
 	}
 
-	withClause := ""
-	if len(withParams) > 0 {
-		withClause = fmt.Sprintf("with(%s)", strings.Join(withParams, ", "))
-	}
-
-	createStatement := fmt.Sprintf("%s function %s\n%s%s\n%s", cmd, withClause, name, parameters, body)
+	// the parameter list directly follows the function name
+	createStatement := kql.New(cmd, "function").With(withParams).Query(kql.Identifier(name) + parameters).Query(body).String()
 
 	client, err := getADXClient(meta, clusterConfig)
 	if err != nil {
 		return diag.Errorf("error creating adx client connection: %+v", err)
 	}
 
-	kStmtOpts := kusto.UnsafeStmt(unsafe.Stmt{Add: true})
-	_, err = client.Mgmt(ctx, databaseName, kusto.NewStmt("", kStmtOpts).UnsafeAdd(createStatement))
+	_, err = executeADXMgmt(ctx, meta, client, databaseName, createStatement)
 	if err != nil {
 		return diag.Errorf("error creating function %s (Database %q): %+v", name, databaseName, err)
 	}
@@ -149,7 +140,8 @@ func resourceADXFunctionRead(ctx context.Context, d *schema.ResourceData, meta i
 		return diag.FromErr(err)
 	}
 
-	resultSet, diags := readADXEntity[ADXFunction](ctx, meta, clusterConfig, id, fmt.Sprintf(".show functions | where Name == '%s'", id.Name), "function")
+	showCommand := kql.New(".show", "functions", "|", "where", "Name", "==").StringLiteral(id.Name).String()
+	resultSet, diags := readADXEntity[ADXFunction](ctx, meta, clusterConfig, id, showCommand, "function")
 	if diags.HasError() {
 		return diags
 	}
@@ -176,7 +168,7 @@ func resourceADXFunctionDelete(ctx context.Context, d *schema.ResourceData, meta
 		return diag.FromErr(err)
 	}
 
-	return deleteADXEntity(ctx, d, meta, clusterConfig, id.DatabaseName, fmt.Sprintf(".drop function %s", id.Name))
+	return deleteADXEntity(ctx, d, meta, clusterConfig, id.DatabaseName, kql.New(".drop", "function").Identifier(id.Name).String())
 }
 
 func parseADXFunctionID(input string) (*adxResourceId, error) {
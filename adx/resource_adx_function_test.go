@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 type ADXFunctionTestResource struct{}
@@ -98,3 +99,40 @@ func (this ADXFunctionTestResource) template(rtc *ResourceTestContext[ADXFunctio
 	}
 	`, rtc.DatabaseName)
 }
+
+func TestFakeADXFunction_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	r := newFakeResource(t, f, resourceADXFunction())
+
+	state := r.apply(map[string]interface{}{
+		"name":          "fake_function",
+		"database_name": "fake_db",
+		"parameters":    "(x:int)",
+		"body":          "{ print x }",
+		"folder":        "fake",
+	})
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|function|fake_function", f.endpoint()), state.ID)
+	assert.Equal(t, "(x:int)", state.Attributes["parameters"])
+	assert.Equal(t, "{ print x }", state.Attributes["body"])
+
+	state = r.apply(map[string]interface{}{
+		"name":          "fake_function",
+		"database_name": "fake_db",
+		"parameters":    "(x:int)",
+		"body":          "{ print x * 2 }",
+		"folder":        "fake",
+		"docstring":     "doubles x",
+	})
+	assert.Equal(t, "{ print x * 2 }", state.Attributes["body"])
+	assert.Equal(t, "doubles x", state.Attributes["docstring"])
+
+	r.checkImport(f, "skip_validation")
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Equal(t, []string{
+		".create function with(folder='fake', skipvalidation=false) fake_function(x:int) { print x }",
+		".alter function with(docstring='doubles x', folder='fake', skipvalidation=false) fake_function(x:int) { print x * 2 }",
+		".drop function fake_function",
+	}, f.controlCommands())
+}
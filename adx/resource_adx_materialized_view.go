@@ -2,13 +2,12 @@ package adx
 
 import (
 	"context"
-	"fmt"
 	"regexp"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/Azure/azure-kusto-go/kusto/data/value"
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
 	"github.com/favoretti/terraform-provider-adx/adx/validate"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -151,39 +150,38 @@ func resourceADXMaterializedViewCreateUpdate(ctx context.Context, d *schema.Reso
 	sourceTableName := d.Get("source_table_name").(string)
 	async := d.Get("async").(bool)
 
-	var withParams []string
+	withParams := kql.NewProperties()
 
 	if backfill, ok := d.GetOk("backfill"); ok && new {
-		withParams = append(withParams, fmt.Sprintf("backfill=%t", backfill.(bool)))
+		withParams.Bool("backfill", backfill.(bool))
 	}
 	if allowMVWithoutRLS, ok := d.GetOk("allow_mv_without_rls"); ok && new {
-		withParams = append(withParams, fmt.Sprintf("allowMaterializedViewsWithoutRowLevelSecurity=%t", allowMVWithoutRLS.(bool)))
+		withParams.Bool("allowMaterializedViewsWithoutRowLevelSecurity", allowMVWithoutRLS.(bool))
 	}
 	if updateExtentsCreationTime, ok := d.GetOk("update_extents_creation_time"); ok && new {
-		withParams = append(withParams, fmt.Sprintf("UpdateExtentsCreationTime=%t", updateExtentsCreationTime.(bool)))
+		withParams.Bool("UpdateExtentsCreationTime", updateExtentsCreationTime.(bool))
 	}
 	if autoUpdateSchema, ok := d.GetOk("auto_update_schema"); ok {
-		withParams = append(withParams, fmt.Sprintf("autoUpdateSchema=%t", autoUpdateSchema.(bool)))
+		withParams.Bool("autoUpdateSchema", autoUpdateSchema.(bool))
 	}
 	if effectiveDateTime, ok := d.GetOk("effective_date_time"); ok && new {
-		withParams = append(withParams, fmt.Sprintf("effectiveDateTime=%s", effectiveDateTime.(string)))
+		effectiveDateTimeLiteral, err := kql.DateTime(effectiveDateTime.(string))
+		if err != nil {
+			return diag.Errorf("error creating materialized-view %s (Database %q): %+v", name, databaseName, err)
+		}
+		withParams.Literal("effectiveDateTime", effectiveDateTimeLiteral)
 	}
 	if docstring, ok := d.GetOk("docstring"); ok {
-		withParams = append(withParams, fmt.Sprintf("docstring='%s'", docstring))
+		withParams.String("docstring", docstring.(string))
 	}
 	if folder, ok := d.GetOk("folder"); ok {
-		withParams = append(withParams, fmt.Sprintf("folder='%s'", folder))
+		withParams.String("folder", folder.(string))
 	}
 	if maxSourceRecordsForSingleIngest, ok := d.GetOk("max_source_records_for_single_ingest"); ok && new {
-		withParams = append(withParams, fmt.Sprintf("MaxSourceRecordsForSingleIngest=%d", maxSourceRecordsForSingleIngest))
+		withParams.Int("MaxSourceRecordsForSingleIngest", maxSourceRecordsForSingleIngest.(int))
 	}
 	if concurrency, ok := d.GetOk("concurrency"); ok && new {
-		withParams = append(withParams, fmt.Sprintf("Concurrency=%d", concurrency))
-	}
-
-	withClause := ""
-	if len(withParams) > 0 {
-		withClause = fmt.Sprintf("with(%s)", strings.Join(withParams, ", "))
+		withParams.Int("Concurrency", concurrency.(int))
 	}
 
 	cmd := ".alter"
@@ -191,15 +189,19 @@ func resourceADXMaterializedViewCreateUpdate(ctx context.Context, d *schema.Reso
 		cmd = ".create"
 	}
 
+	createStatement := kql.New(cmd)
+	if async && new {
+		createStatement.Keyword("async")
+	}
+	createStatement.Keyword("materialized-view").With(withParams).Identifier(name).Keyword("on", "table").Identifier(sourceTableName).Query("\n{\n" + query + "\n}")
+
 	if !async || !new {
-		createStatement := fmt.Sprintf("%s materialized-view %s %s on table %s \n{\n%s\n}", cmd, withClause, name, sourceTableName, query)
-		_, err := queryADXMgmt(ctx, meta, clusterConfig, databaseName, createStatement)
+		_, err := queryADXMgmt(ctx, meta, clusterConfig, databaseName, createStatement.String())
 		if err != nil {
 			return diag.Errorf("error creating materialized-view %s (Database %q): %+v", name, databaseName, err)
 		}
 	} else {
-		createStatement := fmt.Sprintf("%s async materialized-view %s %s on table %s \n{\n%s\n}", cmd, withClause, name, sourceTableName, query)
-		resultSet, err := queryADXMgmtAndParse[adxAsyncOperationResp](ctx, meta, clusterConfig, databaseName, createStatement)
+		resultSet, err := queryADXMgmtAndParse[adxAsyncOperationResp](ctx, meta, clusterConfig, databaseName, createStatement.String())
 		if err != nil {
 			return diag.Errorf("error creating materialized-view %s (Database %q): %+v", name, databaseName, err)
 		}
@@ -226,8 +228,8 @@ func resourceADXMaterializedViewRead(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
-	showCommand := fmt.Sprintf(".show materialized-views | where Name == '%s' | extend Lookback=tostring(Lookback), IsHealthy=tolower(tostring(IsHealthy)), IsEnabled=tolower(tostring(IsEnabled)), AutoUpdateSchema=tolower(tostring(AutoUpdateSchema)), EffectiveDateTime", id.Name)
-	//showCommand := fmt.Sprintf(".show materialized-views | where Name == '%s' | extend Lookback=tostring(Lookback)", id.Name)
+	showCommand := kql.New(".show", "materialized-views", "|", "where", "Name", "==").StringLiteral(id.Name).
+		Keyword("|", "extend", "Lookback=tostring(Lookback), IsHealthy=tolower(tostring(IsHealthy)), IsEnabled=tolower(tostring(IsEnabled)), AutoUpdateSchema=tolower(tostring(AutoUpdateSchema)), EffectiveDateTime").String()
 	resultSet, diags := readADXEntity[ADXMaterializedView](ctx, meta, clusterConfig, id, showCommand, "materialized-view")
 	if diags.HasError() {
 		return diags
@@ -260,7 +262,7 @@ func resourceADXMaterializedViewDelete(ctx context.Context, d *schema.ResourceDa
 		return diag.FromErr(err)
 	}
 
-	return deleteADXEntity(ctx, d, meta, clusterConfig, id.DatabaseName, fmt.Sprintf(".drop materialized-view %s", id.Name))
+	return deleteADXEntity(ctx, d, meta, clusterConfig, id.DatabaseName, kql.New(".drop", "materialized-view").Identifier(id.Name).String())
 }
 
 func parseADXMaterializedViewID(input string) (*adxResourceId, error) {
@@ -3,10 +3,10 @@ package adx
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"regexp"
 	"time"
 
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
 	"github.com/favoretti/terraform-provider-adx/adx/validate"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -68,14 +68,18 @@ func resourceADXMaterializedViewCachingPolicyCreateUpdate(ctx context.Context, d
 	dataHotSpan := d.Get("data_hot_span").(string)
 	followerDatabase := d.Get("follower_database").(bool)
 
-	followerDatabaseClause := ""
-	if followerDatabase {
-		followerDatabaseClause = fmt.Sprintf("follower database %s", escapeEntityNameIfRequired(databaseName))
+	hotSpan, err := kql.Timespan(dataHotSpan)
+	if err != nil {
+		return diag.Errorf("%+v", err)
 	}
 
-	createStatement := fmt.Sprintf(".alter %s materialized-view %s policy caching hot = %s", followerDatabaseClause, viewName, dataHotSpan)
+	createStatement := kql.New(".alter")
+	if followerDatabase {
+		createStatement.Keyword("follower", "database").Identifier(databaseName)
+	}
+	createStatement.Keyword("materialized-view").Identifier(viewName).Keyword("policy", "caching", "hot", "=").Literal(hotSpan)
 
-	if err := createADXPolicy(ctx, d, meta, "materialized-view", "caching", databaseName, viewName, createStatement); err != nil {
+	if err := createADXPolicy(ctx, d, meta, "materialized-view", "caching", databaseName, viewName, createStatement.String()); err != nil {
 		return diag.Errorf("%+v", err)
 	}
 
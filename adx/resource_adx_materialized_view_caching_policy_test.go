@@ -4,8 +4,8 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 type ADXMaterializedViewCachingPolicyTestResource struct{}
@@ -133,5 +133,33 @@ func (this ADXMaterializedViewCachingPolicyTestResource) template(rtc *ResourceT
 		name          = "table_for_mv_%s"
 		table_schema  = "f1:string,f2:string,f4:string,f3:int"
 	}
-	`, rtc.DatabaseName, rtc.EntityName, rtc.DatabaseName, acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	`, rtc.DatabaseName, rtc.EntityName, rtc.DatabaseName, rtc.RandomName())
+}
+
+func TestFakeADXMaterializedViewCachingPolicy_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db").addTable("fake_table", "f1:string")
+	f.database("fake_db").addMaterializedView("fake_view", "fake_table", "fake_table | summarize count() by f1")
+	r := newFakeResource(t, f, resourceADXMaterializedViewCachingPolicy())
+
+	state := r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"view_name":     "fake_view",
+		"data_hot_span": "3d",
+	})
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|materialized-view|fake_view|policy|caching", f.endpoint()), state.ID)
+	assert.Equal(t, "3d", state.Attributes["data_hot_span"])
+
+	state = r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"view_name":     "fake_view",
+		"data_hot_span": "1d",
+	})
+	assert.Equal(t, "1d", state.Attributes["data_hot_span"])
+
+	r.checkImport(f, "data_hot_span", "follower_database")
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Empty(t, f.database("fake_db").policies)
 }
@@ -2,11 +2,11 @@ package adx
 
 import (
 	"context"
-	"fmt"
 	"regexp"
 
 	"encoding/json"
 
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
 	"github.com/favoretti/terraform-provider-adx/adx/validate"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -72,7 +72,13 @@ func resourceADXMaterializedViewRetentionPolicyCreateUpdate(ctx context.Context,
 		recoverabilityString = "disabled"
 	}
 
-	createStatement := fmt.Sprintf(".alter-merge materialized-view %s policy retention softdelete = %s recoverability = %s", viewName, softDeleteTimespan, recoverabilityString)
+	softDelete, err := kql.Timespan(softDeleteTimespan)
+	if err != nil {
+		return diag.Errorf("%+v", err)
+	}
+
+	createStatement := kql.New(".alter-merge", "materialized-view").Identifier(viewName).
+		Keyword("policy", "retention", "softdelete", "=").Literal(softDelete).Keyword("recoverability", "=", recoverabilityString).String()
 
 	if err := createADXPolicy(ctx, d, meta, "materialized-view", "retention", databaseName, viewName, createStatement); err != nil {
 		return diag.Errorf("%+v", err)
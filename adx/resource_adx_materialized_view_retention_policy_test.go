@@ -0,0 +1,39 @@
+package adx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXMaterializedViewRetentionPolicy_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db").addTable("fake_table", "f1:string")
+	f.database("fake_db").addMaterializedView("fake_view", "fake_table", "fake_table | summarize count() by f1")
+	r := newFakeResource(t, f, resourceADXMaterializedViewRetentionPolicy())
+
+	state := r.apply(map[string]interface{}{
+		"database_name":      "fake_db",
+		"view_name":          "fake_view",
+		"soft_delete_period": "30d",
+		"recoverability":     true,
+	})
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|materialized-view|fake_view|policy|retention", f.endpoint()), state.ID)
+	assert.Equal(t, "30d", state.Attributes["soft_delete_period"])
+
+	state = r.apply(map[string]interface{}{
+		"database_name":      "fake_db",
+		"view_name":          "fake_view",
+		"soft_delete_period": "500m",
+		"recoverability":     false,
+	})
+	assert.Equal(t, "500m", state.Attributes["soft_delete_period"])
+	assert.Equal(t, "false", state.Attributes["recoverability"])
+	assert.JSONEq(t, `{"SoftDeletePeriod":"08:20:00","Recoverability":"Disabled"}`, f.database("fake_db").policies["materialized-view|fake_view|retention"])
+
+	r.checkImport(f, "soft_delete_period")
+
+	r.destroy()
+	assert.Empty(t, f.database("fake_db").policies)
+}
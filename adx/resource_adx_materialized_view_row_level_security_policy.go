@@ -2,10 +2,10 @@ package adx
 
 import (
 	"context"
-	"fmt"
 
 	"encoding/json"
 
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
 	"github.com/favoretti/terraform-provider-adx/adx/validate"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -70,7 +70,7 @@ func resourceADXMaterializedViewRowLevelSecurityPolicyCreateUpdate(ctx context.C
 		enabledString = "disable"
 	}
 
-	createStatement := fmt.Sprintf(".alter materialized-view %s policy row_level_security %s \"%s\"", viewName, enabledString, query)
+	createStatement := kql.New(".alter", "materialized-view").Identifier(viewName).Keyword("policy", "row_level_security", enabledString).StringLiteral(query).String()
 
 	if err := createADXPolicy(ctx, d, meta, "materialized-view", "row_level_security", databaseName, viewName, createStatement); err != nil {
 		return diag.Errorf("%+v", err)
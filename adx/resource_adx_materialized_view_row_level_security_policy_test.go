@@ -0,0 +1,42 @@
+package adx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXMaterializedViewRowLevelSecurityPolicy_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db").addTable("fake_table", "f1:string")
+	f.database("fake_db").addMaterializedView("fake_view", "fake_table", "fake_table | summarize count() by f1")
+	r := newFakeResource(t, f, resourceADXMaterializedViewRowLevelSecurityPolicy())
+
+	state := r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"view_name":     "fake_view",
+		"query":         "fake_view | where f1 == 'visible'",
+	})
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|materialized-view|fake_view|policy|row_level_security", f.endpoint()), state.ID)
+	assert.Equal(t, "true", state.Attributes["enabled"])
+
+	state = r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"view_name":     "fake_view",
+		"query":         "fake_view | where f1 != 'hidden'",
+		"enabled":       false,
+	})
+	assert.Equal(t, "false", state.Attributes["enabled"])
+	assert.Equal(t, "fake_view | where f1 != 'hidden'", state.Attributes["query"])
+
+	r.checkImport(f)
+
+	r.destroy()
+	assert.Empty(t, f.database("fake_db").policies)
+	assert.Equal(t, []string{
+		".alter materialized-view fake_view policy row_level_security enable 'fake_view | where f1 == \\'visible\\''",
+		".alter materialized-view fake_view policy row_level_security disable 'fake_view | where f1 != \\'hidden\\''",
+		".delete materialized-view fake_view policy row_level_security",
+	}, f.controlCommands())
+}
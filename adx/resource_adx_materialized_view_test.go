@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 type ADXMaterializedViewTestResource struct{}
@@ -274,3 +275,37 @@ func (this ADXMaterializedViewTestResource) rlsTable(rtc *ResourceTestContext[AD
 	}
 	`, this.basicTable(rtc, tableName), rtc.Label, rtc.DatabaseName, rtc.Label, rtc.Label, rtc.DatabaseName, rtc.Label, rtc.Label)
 }
+
+func TestFakeADXMaterializedView_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db").addTable("fake_table", "f1:string,f2:int")
+	r := newFakeResource(t, f, resourceADXMaterializedView())
+
+	config := map[string]interface{}{
+		"name":              "fake_view",
+		"database_name":     "fake_db",
+		"source_table_name": "fake_table",
+		"query":             "fake_table | summarize count() by f1",
+		"folder":            "fake",
+	}
+	state := r.apply(config)
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|materializedview|fake_view", f.endpoint()), state.ID)
+	assert.Equal(t, "fake_table", state.Attributes["source_table_name"])
+	assert.NotEmpty(t, state.Attributes["effective_date_time"])
+
+	config["query"] = "fake_table | summarize sum(f2) by f1"
+	config["auto_update_schema"] = true
+	state = r.apply(config)
+	assert.Equal(t, "fake_table | summarize sum(f2) by f1", state.Attributes["query"])
+	assert.Equal(t, "true", state.Attributes["auto_update_schema"])
+
+	r.checkImport(f, "async", "backfill", "allow_mv_without_rls", "update_extents_creation_time")
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Equal(t, []string{
+		".create materialized-view with(folder='fake') fake_view on table fake_table \n{\nfake_table | summarize count() by f1\n}",
+		".alter materialized-view with(autoUpdateSchema=true, folder='fake') fake_view on table fake_table \n{\nfake_table | summarize sum(f2) by f1\n}",
+		".drop materialized-view fake_view",
+	}, f.controlCommands())
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
 	"github.com/favoretti/terraform-provider-adx/adx/validate"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -177,12 +178,12 @@ func resourceADXMergePolicyCreateUpdate(ctx context.Context, d *schema.ResourceD
 		policy.Lookback.CustomPeriod = &customPeriod
 	}
 
-	policyJSON, err := json.Marshal(policy)
+	policyJSON, err := kql.JSON(policy)
 	if err != nil {
 		return diag.Errorf("error serializing merge policy: %+v", err)
 	}
 
-	createStatement := fmt.Sprintf(".alter %s %s policy merge @'%s'", kustoEntityType, escapeEntityNameIfRequired(entityName), string(policyJSON))
+	createStatement := kql.New(".alter", kustoEntityType).Identifier(entityName).Keyword("policy", "merge").Literal(policyJSON).String()
 
 	if diags := createADXPolicy(ctx, d, meta, kustoEntityType, "merge", databaseName, entityName, createStatement); diags != nil {
 		return diags
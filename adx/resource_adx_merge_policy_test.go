@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 type ADXMergePolicyTestResource struct{}
@@ -84,3 +85,31 @@ func (this ADXMergePolicyTestResource) template(rtc *ResourceTestContext[TablePo
 	}
 	`, rtc.DatabaseName, rtc.EntityName)
 }
+
+func TestFakeADXMergePolicy_table(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db").addTable("fake_table", "f1:string")
+	r := newFakeResource(t, f, resourceADXMergePolicy())
+
+	config := map[string]interface{}{
+		"database_name": "fake_db",
+		"entity_type":   "table",
+		"entity_name":   "fake_table",
+	}
+	state := r.apply(config)
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|table|fake_table|policy|merge", f.endpoint()), state.ID)
+	assert.Equal(t, "100", state.Attributes["max_extents_to_merge"])
+
+	config["max_extents_to_merge"] = 50
+	config["lookback_kind"] = "Custom"
+	config["lookback_custom_period"] = "2.00:00:00"
+	state = r.apply(config)
+	assert.Equal(t, "50", state.Attributes["max_extents_to_merge"])
+	assert.Equal(t, "2.00:00:00", state.Attributes["lookback_custom_period"])
+
+	r.checkImport(f)
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Empty(t, f.database("fake_db").policies)
+}
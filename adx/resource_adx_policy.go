@@ -0,0 +1,173 @@
+package adx
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
+	"github.com/favoretti/terraform-provider-adx/adx/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// policyEntityKeywords are the command keywords of the entities that can hold policies, keyed by entity_type
+var policyEntityKeywords = map[string]string{
+	"cluster":           "cluster",
+	"database":          "database",
+	"table":             "table",
+	"materialized_view": "materialized-view",
+	"column":            "column",
+	"external_table":    "external table",
+}
+
+func policyEntityTypes() []string {
+	var types []string
+	for entityType := range policyEntityKeywords {
+		types = append(types, entityType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// policyEntityTypeFromKusto maps the command keyword stored in the resource id back to its entity_type
+func policyEntityTypeFromKusto(kustoEntityType string) string {
+	for entityType, keyword := range policyEntityKeywords {
+		if keyword == kustoEntityType {
+			return entityType
+		}
+	}
+	return kustoEntityType
+}
+
+func resourceADXPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceADXPolicyCreateUpdate,
+		ReadContext:   resourceADXPolicyRead,
+		UpdateContext: resourceADXPolicyCreateUpdate,
+		DeleteContext: resourceADXPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster": getClusterConfigInputSchema(),
+			"database_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+				Description:      "Database name of the entity, used as context for the management command when entity_type is cluster.",
+			},
+
+			"entity_type": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringInSlice(policyEntityTypes()),
+			},
+
+			"entity_name": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+				Description:      "Name of the entity, columns are referenced as Table.Column. Not used for cluster and database policies.",
+			},
+
+			"policy_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringMatch(regexp.MustCompile(`^[a-z][a-z_]*$`), "policy_name must be the lower case name used in policy commands, e.g. sharding"),
+			},
+
+			"policy": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validate.StringIsJSON,
+				DiffSuppressFunc: suppressJSONDiff,
+				Description:      "JSON representation of the policy.",
+			},
+		},
+		CustomizeDiff: policyCustomizeDiff,
+	}
+}
+
+func policyCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if err := clusterConfigCustomDiff(ctx, diff, meta); err != nil {
+		return err
+	}
+
+	entityType := diff.Get("entity_type").(string)
+	if entityType != "cluster" && entityType != "database" {
+		entityName, ok := diff.GetOk("entity_name")
+		if !ok || entityName.(string) == "" {
+			return fmt.Errorf("entity_name is required when entity_type is %q", entityType)
+		}
+	}
+	return nil
+}
+
+func resourceADXPolicyCreateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	databaseName := d.Get("database_name").(string)
+	entityType := d.Get("entity_type").(string)
+	kustoEntityType := policyEntityKeywords[entityType]
+	policyName := d.Get("policy_name").(string)
+
+	var entityName string
+	switch entityType {
+	case "cluster":
+		// cluster policies have no entity name, the database is only the command context
+	case "database":
+		entityName = databaseName
+	default:
+		entityName = d.Get("entity_name").(string)
+	}
+
+	createStatement := policyEntity(kql.New(".alter"), kustoEntityType, entityName).Keyword("policy", policyName).StringLiteral(normalizeJSON(d.Get("policy").(string))).String()
+
+	if diags := createADXPolicy(ctx, d, meta, kustoEntityType, policyName, databaseName, entityName, createStatement); diags != nil {
+		return diags
+	}
+
+	return resourceADXPolicyRead(ctx, d, meta)
+}
+
+func resourceADXPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id, err := parseADXPolicyID(d.Id())
+	if err != nil {
+		return diag.Errorf("error parsing policy ID: %+v", err)
+	}
+
+	_, resultSet, diags := readADXPolicy(ctx, d, meta, id.EntityType, id.PolicyName)
+	if diags.HasError() || resultSet == nil || len(resultSet) == 0 {
+		return diags
+	}
+
+	if resultSet[0].Policy == "null" || resultSet[0].Policy == "" {
+		d.SetId("")
+		return diags
+	}
+
+	entityType := policyEntityTypeFromKusto(id.EntityType)
+	d.Set("database_name", id.DatabaseName)
+	d.Set("entity_type", entityType)
+	if entityType != "cluster" && entityType != "database" {
+		d.Set("entity_name", id.Name)
+	}
+	d.Set("policy_name", id.PolicyName)
+	d.Set("policy", normalizeJSON(resultSet[0].Policy))
+
+	return diags
+}
+
+func resourceADXPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id, err := parseADXPolicyID(d.Id())
+	if err != nil {
+		return diag.Errorf("error parsing policy ID: %+v", err)
+	}
+
+	return deleteADXPolicy(ctx, d, meta, id.EntityType, id.PolicyName)
+}
@@ -0,0 +1,96 @@
+package adx
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXPolicy_table(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db").addTable("fake_table", "f1:string")
+	r := newFakeResource(t, f, resourceADXPolicy())
+
+	config := map[string]interface{}{
+		"database_name": "fake_db",
+		"entity_type":   "table",
+		"entity_name":   "fake_table",
+		"policy_name":   "sharding",
+		"policy": `{
+			"MaxRowCount": 750000
+		}`,
+	}
+	state := r.apply(config)
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|table|fake_table|policy|sharding", f.endpoint()), state.ID)
+	assert.Equal(t, `{"MaxRowCount":750000}`, state.Attributes["policy"])
+
+	// properties added by the cluster do not show up as a diff
+	f.database("fake_db").policies["table|fake_table|sharding"] = `{"MaxRowCount":750000,"MaxExtentSizeInMb":1024}`
+	r.refresh()
+	diff, err := r.resource.Diff(context.Background(), r.state, terraform.NewResourceConfigRaw(config), r.meta)
+	assert.NoError(t, err)
+	assert.Nil(t, diff)
+
+	config["policy"] = `{"MaxRowCount": 500000}`
+	state = r.apply(config)
+	assert.Contains(t, state.Attributes["policy"], `"MaxRowCount":500000`)
+
+	r.checkImport(f)
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Empty(t, f.database("fake_db").policies)
+	assert.Equal(t, []string{
+		`.alter table fake_table policy sharding '{"MaxRowCount":750000}'`,
+		`.alter table fake_table policy sharding '{"MaxRowCount":500000}'`,
+		".delete table fake_table policy sharding",
+	}, f.controlCommands())
+}
+
+func TestFakeADXPolicy_cluster(t *testing.T) {
+	f := newFakeKusto(t)
+	r := newFakeResource(t, f, resourceADXPolicy())
+
+	config := map[string]interface{}{
+		"database_name": "fake_db",
+		"entity_type":   "cluster",
+		"policy_name":   "query_weak_consistency",
+		"policy":        `{"PercentageOfNodes": 20, "MinimumNumberOfNodes": 2}`,
+	}
+	state := r.apply(config)
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|cluster||policy|query_weak_consistency", f.endpoint()), state.ID)
+	assert.Equal(t, `{"MinimumNumberOfNodes":2,"PercentageOfNodes":20}`, f.cluster.policies["query_weak_consistency"])
+
+	r.checkImport(f)
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Empty(t, f.cluster.policies)
+	assert.Equal(t, []string{
+		`.alter cluster policy query_weak_consistency '{"MinimumNumberOfNodes":2,"PercentageOfNodes":20}'`,
+		".delete cluster policy query_weak_consistency",
+	}, f.controlCommands())
+}
+
+func TestFakeADXPolicy_externalTable(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db").addExternalTable("fake_external", "f1:string")
+	r := newFakeResource(t, f, resourceADXPolicy())
+
+	state := r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"entity_type":   "external_table",
+		"entity_name":   "fake_external",
+		"policy_name":   "query_acceleration",
+		"policy":        `{"IsEnabled": true, "Hot": "1.00:00:00"}`,
+	})
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|external table|fake_external|policy|query_acceleration", f.endpoint()), state.ID)
+	assert.Equal(t, "external_table", state.Attributes["entity_type"])
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Empty(t, f.database("fake_db").policies)
+}
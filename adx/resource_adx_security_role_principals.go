@@ -0,0 +1,271 @@
+package adx
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-kusto-go/kusto"
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
+	"github.com/favoretti/terraform-provider-adx/adx/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// securityRoleEntityRoles lists the roles that can be assigned on each entity type
+var securityRoleEntityRoles = map[string][]string{
+	"database":          databasePrincipalRoles,
+	"table":             {"admins", "ingestors"},
+	"function":          {"admins"},
+	"materialized_view": {"admins"},
+	"external_table":    {"admins"},
+}
+
+func resourceADXSecurityRolePrincipals() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceADXSecurityRolePrincipalsCreateUpdate,
+		ReadContext:   resourceADXSecurityRolePrincipalsRead,
+		UpdateContext: resourceADXSecurityRolePrincipalsCreateUpdate,
+		DeleteContext: resourceADXSecurityRolePrincipalsDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster": getClusterConfigInputSchema(),
+			"database_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+			},
+
+			"entity_type": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringInSlice([]string{"database", "table"}),
+			},
+
+			"entity_name": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+			},
+
+			"role": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+			},
+
+			"principals": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Fully qualified names of all principals holding the role, e.g. 'aaduser=user@example.com'. Principals that are not listed are removed from the role, an empty list removes all principals.",
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validate.StringIsNotEmpty,
+				},
+			},
+		},
+		CustomizeDiff: securityRolePrincipalsCustomizeDiff,
+	}
+}
+
+func securityRolePrincipalsCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if err := clusterConfigCustomDiff(ctx, diff, meta); err != nil {
+		return err
+	}
+
+	entityType := diff.Get("entity_type").(string)
+	if entityType != "database" {
+		entityName, ok := diff.GetOk("entity_name")
+		if !ok || entityName.(string) == "" {
+			return fmt.Errorf("entity_name is required when entity_type is %q", entityType)
+		}
+	}
+
+	return validateSecurityRole(entityType, diff.Get("role").(string))
+}
+
+// validateSecurityRole checks that role can be assigned on the entity type
+func validateSecurityRole(entityType string, role string) error {
+	roles := securityRoleEntityRoles[entityType]
+	for _, r := range roles {
+		if r == role {
+			return nil
+		}
+	}
+	return fmt.Errorf("role %q is not supported for entity_type %q, expected one of: %s", role, entityType, strings.Join(roles, ", "))
+}
+
+func resourceADXSecurityRolePrincipalsCreateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clusterConfig := getAndExpandClusterConfigWithDefaults(ctx, d, meta)
+
+	databaseName := d.Get("database_name").(string)
+	entityType := d.Get("entity_type").(string)
+	role := d.Get("role").(string)
+
+	entityName := databaseName
+	if entityType != "database" {
+		entityName = d.Get("entity_name").(string)
+	}
+
+	client, err := getADXClient(meta, clusterConfig)
+	if err != nil {
+		return diag.Errorf("error creating adx client connection: %+v", err)
+	}
+
+	principals := expandStringSet(d.Get("principals").(*schema.Set))
+	setStatement := buildSecurityRoleSetStatement(entityType, entityName, role, principals)
+	// the client treats every .set command as a write, although this one does not ingest data
+	if _, err := executeADXMgmt(ctx, meta, client, databaseName, setStatement, kusto.AllowWrite()); err != nil {
+		return diag.Errorf("error setting %s principals of %s %q (Database %q): %+v", role, entityType, entityName, databaseName, err)
+	}
+
+	d.SetId(buildADXResourceId(client.Endpoint(), databaseName, entityType, entityName, "principals", role))
+
+	return resourceADXSecurityRolePrincipalsRead(ctx, d, meta)
+}
+
+func resourceADXSecurityRolePrincipalsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	clusterConfig := getAndExpandClusterConfigWithDefaults(ctx, d, meta)
+
+	id, err := parseADXSecurityRolePrincipalsID(d.Id())
+	if err != nil {
+		return diag.Errorf("error parsing resource ID: %+v", err)
+	}
+
+	if entityExists, err := isEntityExists(ctx, meta, clusterConfig, id.DatabaseName, id.EntityType, id.Name); err != nil || !entityExists {
+		if err != nil {
+			return diag.Errorf("%+v", err)
+		}
+		d.SetId("")
+		return diags
+	}
+
+	showStatement := kql.New(".show", id.EntityType).Identifier(id.Name).Keyword("principals").String()
+	resultSet, err := queryADXMgmtAndParse[TablePrincipal](ctx, meta, clusterConfig, id.DatabaseName, showStatement)
+	if err != nil {
+		return diag.Errorf("error reading principals of %s %q (Database %q): %+v", id.EntityType, id.Name, id.DatabaseName, err)
+	}
+
+	var configured []string
+	if v, ok := d.GetOk("principals"); ok {
+		configured = expandStringSet(v.(*schema.Set))
+	}
+
+	d.Set("database_name", id.DatabaseName)
+	d.Set("entity_type", id.EntityType)
+	if id.EntityType != "database" {
+		d.Set("entity_name", id.Name)
+	}
+	d.Set("role", id.Role)
+	d.Set("principals", flattenSecurityRolePrincipals(resultSet, id.EntityType, id.Role, configured))
+
+	return diags
+}
+
+func resourceADXSecurityRolePrincipalsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clusterConfig := getAndExpandClusterConfigWithDefaults(ctx, d, meta)
+
+	id, err := parseADXSecurityRolePrincipalsID(d.Id())
+	if err != nil {
+		return diag.Errorf("error parsing resource ID: %+v", err)
+	}
+
+	client, err := getADXClient(meta, clusterConfig)
+	if err != nil {
+		return diag.Errorf("error creating adx client connection: %+v", err)
+	}
+
+	setStatement := buildSecurityRoleSetStatement(id.EntityType, id.Name, id.Role, nil)
+	if _, err := executeADXMgmt(ctx, meta, client, id.DatabaseName, setStatement, kusto.AllowWrite()); err != nil {
+		return diag.Errorf("error removing %s principals of %s %q (Database %q): %+v", id.Role, id.EntityType, id.Name, id.DatabaseName, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// buildSecurityRoleSetStatement builds the .set command that replaces all principals of a role, an empty
+// list removes every principal with `none`
+func buildSecurityRoleSetStatement(entityType string, entityName string, role string, principalFQNs []string) string {
+	statement := kql.New(".set", entityType).Identifier(entityName).Keyword(role)
+	if len(principalFQNs) == 0 {
+		return statement.Keyword("none").String()
+	}
+	return statement.StringList(principalFQNs...).String()
+}
+
+// flattenSecurityRolePrincipals returns the FQNs of the principals holding the role on the entity itself.
+// A principal matching a configured FQN is reported with the configured value, as ADX resolves user and
+// group names to object ids; any other principal is reported with its FQN and shows up as drift. Each
+// configured value stands for at most one principal, so that an unmanaged principal is never hidden.
+func flattenSecurityRolePrincipals(principals []TablePrincipal, entityType string, role string, configured []string) []string {
+	var actual []TablePrincipal
+	for _, p := range principals {
+		if matchesRole(p, role) && matchesPrincipalEntityType(p, entityType) {
+			actual = append(actual, p)
+		}
+	}
+
+	reported := make([]string, len(actual))
+	used := make([]bool, len(configured))
+	// exact matches are paired first, so that fuzzy matching cannot take a value another principal equals
+	for i, p := range actual {
+		for j, c := range configured {
+			if !used[j] && strings.EqualFold(p.PrincipalFQN, c) {
+				reported[i], used[j] = c, true
+				break
+			}
+		}
+	}
+	for i, p := range actual {
+		if reported[i] != "" {
+			continue
+		}
+		reported[i] = p.PrincipalFQN
+		for j, c := range configured {
+			if !used[j] && principalKind(c) == principalKind(p.PrincipalFQN) && matchesPrincipal(p, c) {
+				reported[i], used[j] = c, true
+				break
+			}
+		}
+	}
+
+	sort.Strings(reported)
+	return reported
+}
+
+// matchesPrincipalEntityType checks that a principal was granted on the entity type, e.g. "Table Admin",
+// the principals of a table also include those inherited from its database
+func matchesPrincipalEntityType(p TablePrincipal, entityType string) bool {
+	return strings.HasPrefix(strings.ToLower(p.Role), entityType+" ")
+}
+
+type adxSecurityRolePrincipalsResourceId struct {
+	adxResourceId
+	Role string
+}
+
+func parseADXSecurityRolePrincipalsID(input string) (*adxSecurityRolePrincipalsResourceId, error) {
+	id, err := parseADXResourceID(input, 6, 0, 1, 2, 3)
+	if err != nil {
+		return nil, err
+	}
+	parts := strings.Split(input, "|")
+	if parts[4] != "principals" {
+		return nil, fmt.Errorf("error parsing ADX security role principals resource ID: unexpected format: %q, expected <cluster>|<database>|<entity_type>|<entity_name>|principals|<role>", input)
+	}
+
+	return &adxSecurityRolePrincipalsResourceId{
+		adxResourceId: *id,
+		Role:          parts[5],
+	}, nil
+}
@@ -0,0 +1,106 @@
+package adx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXSecurityRolePrincipals_table(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db").addTable("fake_table", "f1:string")
+	table := f.database("fake_db").tables["fake_table"]
+	table.principals, _ = addPrincipals(table.principals, "admins", []string{"aaduser=outofband@example.com"}, "")
+	r := newFakeResource(t, f, resourceADXSecurityRolePrincipals())
+
+	app := "aadapp=4c7e82bd-6adb-46c3-b413-fdd44834c69b;" + fakeKustoTenantID
+	config := map[string]interface{}{
+		"database_name": "fake_db",
+		"entity_type":   "table",
+		"entity_name":   "fake_table",
+		"role":          "admins",
+		"principals":    []interface{}{"aaduser=user@example.com", app},
+	}
+	state := r.apply(config)
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|table|fake_table|principals|admins", f.endpoint()), state.ID)
+	assert.Equal(t, "2", state.Attributes["principals.#"])
+	assert.Len(t, table.principals, 2, "principals that are not configured should be removed")
+
+	// a principal added out-of-band shows up as drift and is removed by the next apply
+	table.principals, _ = addPrincipals(table.principals, "admins", []string{"aaduser=outofband@example.com"}, "")
+	state = r.refresh()
+	assert.Equal(t, "3", state.Attributes["principals.#"])
+	r.apply(config)
+	assert.Len(t, table.principals, 2)
+
+	imported := newFakeResource(t, f, resourceADXSecurityRolePrincipals()).importState(state.ID)
+	assert.Equal(t, "2", imported.Attributes["principals.#"])
+	assert.Equal(t, "fake_table", imported.Attributes["entity_name"])
+
+	r.destroy()
+	assert.Empty(t, table.principals)
+	assert.Equal(t, []string{
+		fmt.Sprintf(".set table fake_table admins ('%s', 'aaduser=user@example.com')", app),
+		fmt.Sprintf(".set table fake_table admins ('%s', 'aaduser=user@example.com')", app),
+		".set table fake_table admins none",
+	}, f.controlCommands())
+}
+
+func TestFakeADXSecurityRolePrincipals_database(t *testing.T) {
+	f := newFakeKusto(t)
+	db := f.database("fake_db")
+	db.principals, _ = addPrincipals(db.principals, "viewers", []string{"aadgroup=others@example.com"}, "")
+	db.principals, _ = addPrincipals(db.principals, "unrestrictedviewers", []string{"aadgroup=readers@example.com"}, "")
+	r := newFakeResource(t, f, resourceADXSecurityRolePrincipals())
+
+	config := map[string]interface{}{
+		"database_name": "fake_db",
+		"entity_type":   "database",
+		"role":          "viewers",
+		"principals":    []interface{}{"aadgroup=readers@example.com"},
+	}
+	state := r.apply(config)
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|database|fake_db|principals|viewers", f.endpoint()), state.ID)
+	assert.Equal(t, "1", state.Attributes["principals.#"])
+	assert.Len(t, db.principals, 2, "other roles should not be changed")
+
+	config["principals"] = []interface{}{}
+	state = r.apply(config)
+	assert.Equal(t, "0", state.Attributes["principals.#"])
+	r.checkImport(f)
+
+	r.destroy()
+	assert.Equal(t, []string{
+		".set database fake_db viewers ('aadgroup=readers@example.com')",
+		".set database fake_db viewers none",
+		".set database fake_db viewers none",
+	}, f.controlCommands())
+}
+
+func TestUtils_validateSecurityRole(t *testing.T) {
+	assert.NoError(t, validateSecurityRole("database", "unrestrictedviewers"))
+	assert.NoError(t, validateSecurityRole("table", "ingestors"))
+	assert.ErrorContains(t, validateSecurityRole("table", "viewers"), "expected one of: admins, ingestors")
+}
+
+func TestADXSecurityRolePrincipals_flattenSecurityRolePrincipals(t *testing.T) {
+	principals := []TablePrincipal{
+		{Role: "Table Admin", PrincipalFQN: "aadapp=4c7e82bd;" + fakeKustoTenantID},
+		{Role: "Table Admin", PrincipalFQN: "aadapp=4c7e82bd-6adb;" + fakeKustoTenantID},
+		{Role: "Table Admin", PrincipalFQN: "aadgroup=0a1b2c3d;" + fakeKustoTenantID, PrincipalDisplayName: "Fake Principal (upn: team@example.com)"},
+		{Role: "Table Ingestor", PrincipalFQN: "aaduser=other@example.com"},
+	}
+
+	// a configured value matches one principal only, the other one is reported as is
+	assert.Equal(t, []string{"aadapp=4c7e82bd", "aadapp=4c7e82bd-6adb;" + fakeKustoTenantID, "aadgroup=0a1b2c3d;" + fakeKustoTenantID},
+		flattenSecurityRolePrincipals(principals[:3], "table", "admins", []string{"aadapp=4c7e82bd"}))
+
+	// a user does not match a group with the same name
+	assert.Equal(t, []string{"aadapp=4c7e82bd-6adb;" + fakeKustoTenantID, "aadapp=4c7e82bd;" + fakeKustoTenantID, "aadgroup=team@example.com"},
+		flattenSecurityRolePrincipals(principals, "table", "admins", []string{"aaduser=team@example.com", "aadgroup=team@example.com"}))
+
+	// an exact match takes precedence over a fuzzy one
+	assert.Equal(t, []string{"aadapp=4c7e82bd-6adb;" + fakeKustoTenantID, "aadapp=4c7e82bd;" + fakeKustoTenantID, "aadgroup=0a1b2c3d;" + fakeKustoTenantID},
+		flattenSecurityRolePrincipals(principals[:3], "table", "admins", []string{"aadapp=4c7e82bd-6adb;" + fakeKustoTenantID, "aadapp=4c7e82bd;" + fakeKustoTenantID}))
+}
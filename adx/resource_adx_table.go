@@ -8,7 +8,7 @@ import (
 
 	"github.com/Azure/azure-kusto-go/kusto"
 	"github.com/Azure/azure-kusto-go/kusto/data/table"
-	"github.com/Azure/azure-kusto-go/kusto/unsafe"
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
 	"github.com/favoretti/terraform-provider-adx/adx/validate"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -173,17 +173,19 @@ func resourceADXTableCreate(ctx context.Context, d *schema.ResourceData, meta in
 	if fromQueryList, ok := d.GetOk("from_query"); ok {
 		createStatement = buildTableFromQueryStatement(tableName, true, getTableFromQueryConfig(fromQueryList.([]interface{})), d)
 	} else {
-		withClause := buildTableWithClause(d)
-		createStatement = fmt.Sprintf(".create table %s (%s) %s", tableName, getTableDefinition(d), withClause)
+		createStatement = kql.New(".create", "table").Identifier(tableName).Literal("(" + getTableDefinition(d) + ")").With(buildTableWithClause(d)).String()
 	}
 
-	kStmtOpts := kusto.UnsafeStmt(unsafe.Stmt{Add: true})
 	client, err := getADXClient(meta, clusterConfig)
 	if err != nil {
 		return diag.Errorf("error creating adx client connection: %+v", err)
 	}
 
-	_, err = client.Mgmt(ctx, databaseName, kusto.NewStmt("", kStmtOpts).UnsafeAdd(createStatement), kusto.AllowWrite())
+	execute := executeADXMgmt
+	if _, ok := d.GetOk("from_query"); ok {
+		execute = executeADXIngestMgmt
+	}
+	_, err = execute(ctx, meta, client, databaseName, createStatement, kusto.AllowWrite())
 	if err != nil {
 		return diag.Errorf("error creating Table %q (Database %q): %+v", tableName, databaseName, err)
 	}
@@ -211,17 +213,19 @@ func resourceADXTableUpdate(ctx context.Context, d *schema.ResourceData, meta in
 		if mergeOnUpdate {
 			alterCmd = ".alter-merge"
 		}
-		withClause := buildTableWithClause(d)
-		createStatement = fmt.Sprintf("%s table %s (%s) %s", alterCmd, tableName, getTableDefinition(d), withClause)
+		createStatement = kql.New(alterCmd, "table").Identifier(tableName).Literal("(" + getTableDefinition(d) + ")").With(buildTableWithClause(d)).String()
 	}
 
-	kStmtOpts := kusto.UnsafeStmt(unsafe.Stmt{Add: true})
 	client, err := getADXClient(meta, clusterConfig)
 	if err != nil {
 		return diag.Errorf("error creating adx client connection: %+v", err)
 	}
 
-	_, err = client.Mgmt(ctx, databaseName, kusto.NewStmt("", kStmtOpts).UnsafeAdd(createStatement), kusto.AllowWrite())
+	execute := executeADXMgmt
+	if _, ok := d.GetOk("from_query"); ok {
+		execute = executeADXIngestMgmt
+	}
+	_, err = execute(ctx, meta, client, databaseName, createStatement, kusto.AllowWrite())
 	if err != nil {
 		return diag.Errorf("error updating Table %q (Database %q): %+v", tableName, databaseName, err)
 	}
@@ -276,10 +280,9 @@ func resourceADXTableRead(ctx context.Context, d *schema.ResourceData, meta inte
 		return diags
 	}
 
-	kStmtOpts := kusto.UnsafeStmt(unsafe.Stmt{Add: true})
-	showStatement := fmt.Sprintf(".show table %s cslschema", id.Name)
+	showStatement := kql.New(".show", "table").Identifier(id.Name).Keyword("cslschema").String()
 
-	resp, err := client.Mgmt(ctx, id.DatabaseName, kusto.NewStmt("", kStmtOpts).UnsafeAdd(showStatement))
+	resp, err := executeADXMgmt(ctx, meta, client, id.DatabaseName, showStatement)
 	if err != nil {
 		return diag.Errorf("error reading Table %q (Database %q): %+v", id.Name, id.DatabaseName, err)
 	}
@@ -327,32 +330,27 @@ func resourceADXTableDelete(ctx context.Context, d *schema.ResourceData, meta in
 		return diag.FromErr(err)
 	}
 
-	return deleteADXEntity(ctx, d, meta, clusterConfig, id.DatabaseName, fmt.Sprintf(".drop table %s", id.Name))
+	return deleteADXEntity(ctx, d, meta, clusterConfig, id.DatabaseName, kql.New(".drop", "table").Identifier(id.Name).String())
 }
 
 func buildTableFromQueryStatement(tableName string, new bool, config *tableFromQueryConfig, d *schema.ResourceData) string {
-	var withParams []string
+	withParams := kql.NewProperties()
 
 	if config.Distributed {
-		withParams = append(withParams, "distributed=true")
+		withParams.Bool("distributed", true)
 	}
 	if config.ExtendSchema {
-		withParams = append(withParams, "extend_schema=true")
+		withParams.Bool("extend_schema", true)
 	}
 	// recreate_schema Only applies for .set-or-replace
 	if config.RecreateSchema && !new {
-		withParams = append(withParams, "recreate_schema=true")
+		withParams.Bool("recreate_schema", true)
 	}
 	if docstring, ok := d.GetOk("docstring"); ok {
-		withParams = append(withParams, fmt.Sprintf("docstring='%s'", docstring))
+		withParams.String("docstring", docstring.(string))
 	}
 	if folder, ok := d.GetOk("folder"); ok {
-		withParams = append(withParams, fmt.Sprintf("folder='%s'", folder))
-	}
-
-	withParamsString := ""
-	if len(withParams) > 0 {
-		withParamsString = fmt.Sprintf("with(%s)", strings.Join(withParams, ","))
+		withParams.String("folder", folder.(string))
 	}
 
 	cmd := ".set-or-append"
@@ -364,7 +362,7 @@ func buildTableFromQueryStatement(tableName string, new bool, config *tableFromQ
 		}
 	}
 
-	return fmt.Sprintf("%s %s %s <| %s", cmd, tableName, withParamsString, config.Query)
+	return kql.New(cmd).Identifier(tableName).With(withParams).Keyword("<|").Query(config.Query).String()
 }
 
 func expandTableColumn(input []interface{}) string {
@@ -375,7 +373,7 @@ func expandTableColumn(input []interface{}) string {
 	columns := make([]string, 0)
 	for _, v := range input {
 		block := v.(map[string]interface{})
-		column := fmt.Sprintf("%s:%s", block["name"].(string), block["type"].(string))
+		column := fmt.Sprintf("%s:%s", kql.Identifier(block["name"].(string)), block["type"].(string))
 		columns = append(columns, column)
 	}
 	return strings.Join(columns, ",")
@@ -387,42 +385,51 @@ func flattenTableColumn(input string) []interface{} {
 	}
 
 	columns := make([]interface{}, 0)
-	for _, v := range strings.Split(input, ",") {
+	for _, v := range kql.SplitList(input) {
+		name, columnType := splitSchemaColumn(v)
 		block := make(map[string]interface{})
-		fields := strings.Split(v, ":")
-		block["name"] = fields[0]
-		block["type"] = fields[1]
+		block["name"] = kql.UnquoteIdentifier(strings.TrimSpace(name))
+		block["type"] = strings.TrimSpace(columnType)
 		columns = append(columns, block)
 	}
 	return columns
 }
 
 func unescapeTableSchema(inlineSchema string) string {
-	schemaColumns := strings.Split(inlineSchema, ",")
+	schemaColumns := kql.SplitList(inlineSchema)
 	for i, column := range schemaColumns {
-		schemaColumns[i] = strings.ReplaceAll(strings.ReplaceAll(column, "['", ""), "']", "")
+		if !strings.Contains(column, ":") {
+			continue
+		}
+		name, columnType := splitSchemaColumn(column)
+		trimmed := strings.TrimLeft(name, " ")
+		schemaColumns[i] = name[:len(name)-len(trimmed)] + kql.UnquoteIdentifier(trimmed) + ":" + columnType
 	}
 	return strings.Join(schemaColumns, ",")
 }
 
+// splitSchemaColumn splits a name:type column definition, the name may be a quoted identifier containing ':'
+func splitSchemaColumn(column string) (string, string) {
+	i := strings.LastIndex(column, ":")
+	if i < 0 {
+		return column, ""
+	}
+	return column[:i], column[i+1:]
+}
+
 func parseADXTableID(input string) (*adxResourceId, error) {
 	return parseADXResourceID(input, 4, 0, 1, 2, 3)
 }
 
-func buildTableWithClause(d *schema.ResourceData) string {
-	var withParams []string
+func buildTableWithClause(d *schema.ResourceData) *kql.Properties {
+	withParams := kql.NewProperties()
 
 	if docstring, ok := d.GetOk("docstring"); ok {
-		withParams = append(withParams, fmt.Sprintf("docstring='%s'", docstring))
+		withParams.String("docstring", docstring.(string))
 	}
 	if folder, ok := d.GetOk("folder"); ok {
-		withParams = append(withParams, fmt.Sprintf("folder='%s'", folder))
-	}
-
-	withClause := ""
-	if len(withParams) > 0 {
-		withClause = fmt.Sprintf("with(%s)", strings.Join(withParams, ", "))
+		withParams.String("folder", folder.(string))
 	}
 
-	return withClause
+	return withParams
 }
@@ -0,0 +1,180 @@
+package adx
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
+	"github.com/favoretti/terraform-provider-adx/adx/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type TableAutoDeletePolicy struct {
+	ExpiryDate       string
+	DeleteIfNotEmpty bool
+}
+
+func resourceADXTableAutoDeletePolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceADXTableAutoDeletePolicyCreateUpdate,
+		ReadContext:   resourceADXTableAutoDeletePolicyRead,
+		DeleteContext: resourceADXTableAutoDeletePolicyDelete,
+		UpdateContext: resourceADXTableAutoDeletePolicyCreateUpdate,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster": getClusterConfigInputSchema(),
+			"database_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+			},
+
+			"table_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+			},
+
+			"expiry_date": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+				DiffSuppressFunc: suppressDateTimeDiff,
+				Description:      "Date and time (RFC3339, e.g. 2025-01-01T00:00:00Z) after which the table is deleted",
+			},
+
+			"delete_if_not_empty": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the table is deleted even if it contains records",
+			},
+		},
+		CustomizeDiff: clusterConfigCustomDiff,
+	}
+}
+
+func resourceADXTableAutoDeletePolicyCreateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tableName := d.Get("table_name").(string)
+	databaseName := d.Get("database_name").(string)
+
+	policyJSON, err := kql.JSON(TableAutoDeletePolicy{
+		ExpiryDate:       d.Get("expiry_date").(string),
+		DeleteIfNotEmpty: d.Get("delete_if_not_empty").(bool),
+	})
+	if err != nil {
+		return diag.Errorf("error serializing policy auto_delete for Table %q (Database %q): %+v", tableName, databaseName, err)
+	}
+
+	createStatement := kql.New(".alter", "table").Identifier(tableName).Keyword("policy", "auto_delete").Literal(policyJSON).String()
+
+	if err := createADXPolicy(ctx, d, meta, "table", "auto_delete", databaseName, tableName, createStatement); err != nil {
+		return diag.Errorf("%+v", err)
+	}
+
+	return resourceADXTableAutoDeletePolicyRead(ctx, d, meta)
+}
+
+func resourceADXTableAutoDeletePolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id, resultSet, diags := readADXPolicy(ctx, d, meta, "table", "auto_delete")
+	if diags.HasError() {
+		// the table may have been deleted by the policy between the existence check and reading the policy
+		if gone, _ := isAutoDeletedTableGone(ctx, d, meta, id); gone {
+			d.SetId("")
+			return nil
+		}
+		return diags
+	}
+	if resultSet == nil || len(resultSet) == 0 {
+		return diags
+	}
+
+	if resultSet[0].Policy == "null" {
+		d.SetId("")
+		return diags
+	}
+
+	var policy TableAutoDeletePolicy
+	if err := json.Unmarshal([]byte(resultSet[0].Policy), &policy); err != nil {
+		return diag.Errorf("error parsing policy auto_delete for Table %q (Database %q): %+v", id.Name, id.DatabaseName, err)
+	}
+
+	// ADX returns the expiry date in its own format, keep the configured value when it is the same time
+	expiryDate := policy.ExpiryDate
+	if configured, ok := d.GetOk("expiry_date"); ok && equalDateTimes(configured.(string), expiryDate) {
+		expiryDate = configured.(string)
+	}
+
+	d.Set("table_name", id.Name)
+	d.Set("database_name", id.DatabaseName)
+	d.Set("expiry_date", expiryDate)
+	d.Set("delete_if_not_empty", policy.DeleteIfNotEmpty)
+
+	return diags
+}
+
+func resourceADXTableAutoDeletePolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id, err := parseADXPolicyID(d.Id())
+	if err != nil {
+		return diag.Errorf("could not delete adx policy due to error parsing ID: %+v", err)
+	}
+
+	// once the table has expired there is no policy left to delete
+	gone, err := isAutoDeletedTableGone(ctx, d, meta, id)
+	if err != nil {
+		return diag.Errorf("%+v", err)
+	}
+	if gone {
+		d.SetId("")
+		return nil
+	}
+
+	return deleteADXPolicy(ctx, d, meta, "table", "auto_delete")
+}
+
+func isAutoDeletedTableGone(ctx context.Context, d *schema.ResourceData, meta interface{}, id *adxPolicyResource) (bool, error) {
+	if id == nil {
+		return false, nil
+	}
+	clusterConfig := getAndExpandClusterConfigWithDefaults(ctx, d, meta)
+	exists, err := isTableExists(ctx, meta, clusterConfig, id.DatabaseName, id.Name)
+	if err != nil {
+		return false, err
+	}
+	return !exists, nil
+}
+
+// suppressDateTimeDiff suppresses diffs between two representations of the same point in time
+func suppressDateTimeDiff(k, old, new string, d *schema.ResourceData) bool {
+	return equalDateTimes(old, new)
+}
+
+// equalDateTimes compares datetimes given as RFC3339 or as ADX formats them, e.g. 2025-01-01T00:00:00.0000000Z.
+// Values without a time zone are taken as UTC, as in ADX.
+func equalDateTimes(a string, b string) bool {
+	timeA, errA := parseADXDateTime(a)
+	timeB, errB := parseADXDateTime(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return timeA.Equal(timeB)
+}
+
+func parseADXDateTime(value string) (time.Time, error) {
+	var t time.Time
+	var err error
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02 15:04:05.999999999", "2006-01-02"} {
+		if t, err = time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return t, err
+}
@@ -0,0 +1,67 @@
+package adx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXTableAutoDeletePolicy_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db").addTable("fake_table", "f1:string")
+	r := newFakeResource(t, f, resourceADXTableAutoDeletePolicy())
+
+	config := map[string]interface{}{
+		"database_name": "fake_db",
+		"table_name":    "fake_table",
+		"expiry_date":   "2030-01-01T00:00:00Z",
+	}
+	state := r.apply(config)
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|table|fake_table|policy|auto_delete", f.endpoint()), state.ID)
+	assert.Equal(t, "2030-01-01T00:00:00Z", state.Attributes["expiry_date"])
+	assert.Equal(t, "true", state.Attributes["delete_if_not_empty"])
+
+	// ADX formats the expiry date with ticks
+	f.database("fake_db").policies["table|fake_table|auto_delete"] = `{"ExpiryDate":"2030-01-01T00:00:00.0000000Z","DeleteIfNotEmpty":true}`
+	state = r.refresh()
+	assert.Equal(t, "2030-01-01T00:00:00Z", state.Attributes["expiry_date"])
+
+	config["expiry_date"] = "2030-06-01T12:00:00+02:00"
+	config["delete_if_not_empty"] = false
+	state = r.apply(config)
+	assert.Equal(t, "false", state.Attributes["delete_if_not_empty"])
+
+	r.checkImport(f)
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Empty(t, f.database("fake_db").policies)
+	assert.Equal(t, []string{
+		`.alter table fake_table policy auto_delete '{"ExpiryDate":"2030-01-01T00:00:00Z","DeleteIfNotEmpty":true}'`,
+		`.alter table fake_table policy auto_delete '{"ExpiryDate":"2030-06-01T12:00:00+02:00","DeleteIfNotEmpty":false}'`,
+		".delete table fake_table policy auto_delete",
+	}, f.controlCommands())
+}
+
+func TestFakeADXTableAutoDeletePolicy_expired(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db").addTable("fake_table", "f1:string")
+	r := newFakeResource(t, f, resourceADXTableAutoDeletePolicy())
+
+	r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"table_name":    "fake_table",
+		"expiry_date":   "2030-01-01T00:00:00Z",
+	})
+
+	// the table was deleted by the policy, destroying the policy is then a no-op
+	delete(f.database("fake_db").tables, "fake_table")
+	r.destroy()
+	assert.Equal(t, []string{
+		`.alter table fake_table policy auto_delete '{"ExpiryDate":"2030-01-01T00:00:00Z","DeleteIfNotEmpty":true}'`,
+	}, f.controlCommands())
+
+	// and reading it removes it from state
+	r.checkDestroyed()
+}
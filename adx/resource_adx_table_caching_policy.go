@@ -2,12 +2,12 @@ package adx
 
 import (
 	"context"
-	"fmt"
 	"regexp"
 	"time"
 
 	"encoding/json"
 
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
 	"github.com/favoretti/terraform-provider-adx/adx/validate"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -69,14 +69,18 @@ func resourceADXTableCachingPolicyCreateUpdate(ctx context.Context, d *schema.Re
 	dataHotSpan := d.Get("data_hot_span").(string)
 	followerDatabase := d.Get("follower_database").(bool)
 
-	followerDatabaseClause := ""
-	if followerDatabase {
-		followerDatabaseClause = fmt.Sprintf("follower database %s", escapeEntityNameIfRequired(databaseName))
+	hotSpan, err := kql.Timespan(dataHotSpan)
+	if err != nil {
+		return diag.Errorf("%+v", err)
 	}
 
-	createStatement := fmt.Sprintf(".alter %s table %s policy caching hot = %s", followerDatabaseClause, tableName, dataHotSpan)
+	createStatement := kql.New(".alter")
+	if followerDatabase {
+		createStatement.Keyword("follower", "database").Identifier(databaseName)
+	}
+	createStatement.Keyword("table").Identifier(tableName).Keyword("policy", "caching", "hot", "=").Literal(hotSpan)
 
-	if err := createADXPolicy(ctx, d, meta, "table", "caching", databaseName, tableName, createStatement); err != nil {
+	if err := createADXPolicy(ctx, d, meta, "table", "caching", databaseName, tableName, createStatement.String()); err != nil {
 		return diag.Errorf("%+v", err)
 	}
 
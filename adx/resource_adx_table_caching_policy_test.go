@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 type ADXTableCachingPolicyTestResource struct{}
@@ -127,3 +128,35 @@ func (this ADXTableCachingPolicyTestResource) template(rtc *ResourceTestContext[
 	}
 	`, rtc.DatabaseName, rtc.EntityName)
 }
+
+func TestFakeADXTableCachingPolicy_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db").addTable("fake_table", "f1:string")
+	r := newFakeResource(t, f, resourceADXTableCachingPolicy())
+
+	state := r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"table_name":    "fake_table",
+		"data_hot_span": "3d",
+	})
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|table|fake_table|policy|caching", f.endpoint()), state.ID)
+	assert.Equal(t, "3d", state.Attributes["data_hot_span"])
+
+	state = r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"table_name":    "fake_table",
+		"data_hot_span": "36h",
+	})
+	assert.Equal(t, "36h", state.Attributes["data_hot_span"])
+	assert.JSONEq(t, `{"DataHotSpan":{"Value":"1.12:00:00"},"IndexHotSpan":{"Value":"1.12:00:00"}}`, f.database("fake_db").policies["table|fake_table|caching"])
+
+	r.checkImport(f, "data_hot_span", "follower_database")
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Equal(t, []string{
+		".alter table fake_table policy caching hot = 3d",
+		".alter table fake_table policy caching hot = 36h",
+		".delete table fake_table policy caching",
+	}, f.controlCommands())
+}
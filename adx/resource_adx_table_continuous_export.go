@@ -2,10 +2,9 @@ package adx
 
 import (
 	"context"
-	"fmt"
-	"strings"
 	"time"
 
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
 	"github.com/favoretti/terraform-provider-adx/adx/validate"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -114,39 +113,38 @@ func resourceADXContinuousExportCreateUpdate(ctx context.Context, d *schema.Reso
 	query := d.Get("query").(string)
 	externalTableName := d.Get("external_table_name").(string)
 
-	var withParams []string
+	withParams := kql.NewProperties()
 
 	if intervalBetweenRuns, ok := d.GetOk("interval_between_runs"); ok {
-		withParams = append(withParams, fmt.Sprintf("intervalBetweenRuns='%s'", intervalBetweenRuns.(string)))
+		withParams.String("intervalBetweenRuns", intervalBetweenRuns.(string))
 	}
 	if forcedLatency, ok := d.GetOk("forced_latency"); ok {
-		withParams = append(withParams, fmt.Sprintf("forcedLatency=%s", forcedLatency.(string)))
+		latency, err := kql.Timespan(forcedLatency.(string))
+		if err != nil {
+			return diag.Errorf("%+v", err)
+		}
+		withParams.Literal("forcedLatency", latency)
 	}
 	if sizeLimit, ok := d.GetOk("size_limit"); ok {
-		withParams = append(withParams, fmt.Sprintf("sizeLimit=%d", sizeLimit.(int)))
+		withParams.Int("sizeLimit", sizeLimit.(int))
 	}
 	if distributed, ok := d.GetOk("distributed"); ok {
-		withParams = append(withParams, fmt.Sprintf("distributed=%t", distributed.(bool)))
+		withParams.Bool("distributed", distributed.(bool))
 	}
 	if parquetRowGroupSize, ok := d.GetOk("parquet_row_group_size"); ok {
-		withParams = append(withParams, fmt.Sprintf("parquetRowGroupSize=%d", parquetRowGroupSize.(int)))
+		withParams.Int("parquetRowGroupSize", parquetRowGroupSize.(int))
 	}
 	if useNativeParquetWriter, ok := d.GetOk("use_native_parquet_writer"); ok {
-		withParams = append(withParams, fmt.Sprintf("useNativeParquetWriter='%s'", useNativeParquetWriter.(string)))
+		withParams.String("useNativeParquetWriter", useNativeParquetWriter.(string))
 	}
 	if managedIdentity, ok := d.GetOk("managed_identity"); ok {
-		withParams = append(withParams, fmt.Sprintf("managedIdentity='%s'", managedIdentity.(string)))
+		withParams.String("managedIdentity", managedIdentity.(string))
 	}
 	if isDisabled, ok := d.GetOk("is_disabled"); ok {
-		withParams = append(withParams, fmt.Sprintf("isDisabled=%t", isDisabled.(bool)))
+		withParams.Bool("isDisabled", isDisabled.(bool))
 	}
 
-	withClause := ""
-	if len(withParams) > 0 {
-		withClause = fmt.Sprintf("with(%s)", strings.Join(withParams, ", "))
-	}
-
-	createStatement := fmt.Sprintf(".create-or-alter continuous-export %s to table %s %s <| %s", name, externalTableName, withClause, query)
+	createStatement := kql.New(".create-or-alter", "continuous-export").Identifier(name).Keyword("to", "table").Identifier(externalTableName).With(withParams).Keyword("<|").Query(query).String()
 	_, err := queryADXMgmt(ctx, meta, clusterConfig, databaseName, createStatement)
 	if err != nil {
 		return diag.Errorf("error creating continuous-export %s (Database %q): %+v", name, databaseName, err)
@@ -169,7 +167,7 @@ func resourceADXContinuousExportRead(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
-	showCommand := fmt.Sprintf(".show continuous-export %s | project Name, ExternalTableName, Query", id.Name)
+	showCommand := kql.New(".show", "continuous-export").Identifier(id.Name).Query("| project Name, ExternalTableName, Query").String()
 
 	resultSet, diags := readADXEntity[ADXContinuousExport](ctx, meta, clusterConfig, id, showCommand, "continuous-export")
 	if diags.HasError() {
@@ -196,7 +194,7 @@ func resourceADXContinuousExportDelete(ctx context.Context, d *schema.ResourceDa
 		return diag.FromErr(err)
 	}
 
-	return deleteADXEntity(ctx, d, meta, clusterConfig, id.DatabaseName, fmt.Sprintf(".drop continuous-export %s", id.Name))
+	return deleteADXEntity(ctx, d, meta, clusterConfig, id.DatabaseName, kql.New(".drop", "continuous-export").Identifier(id.Name).String())
 }
 
 func parseADXContinuousExportID(input string) (*adxResourceId, error) {
@@ -0,0 +1,39 @@
+package adx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXTableContinuousExport_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db").addTable("fake_table", "f1:string")
+	f.database("fake_db").addExternalTable("fake_external", "f1:string")
+	r := newFakeResource(t, f, resourceADXTableContinuousExport())
+
+	config := map[string]interface{}{
+		"name":                "fake_export",
+		"database_name":       "fake_db",
+		"external_table_name": "fake_external",
+		"query":               "fake_table",
+	}
+	state := r.apply(config)
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|continuousexport|fake_export", f.endpoint()), state.ID)
+
+	config["query"] = "fake_table | where isnotempty(f1)"
+	config["interval_between_runs"] = "1h"
+	r.apply(config)
+	assert.Equal(t, "fake_table | where isnotempty(f1)", f.database("fake_db").continuousExports["fake_export"].query)
+
+	r.checkImport(f, "external_table_name", "query", "interval_between_runs", "size_limit", "distributed", "parquet_row_group_size", "is_disabled", "use_native_parquet_writer")
+
+	r.destroy()
+	assert.Empty(t, f.database("fake_db").continuousExports)
+	assert.Equal(t, []string{
+		".create-or-alter continuous-export fake_export to table fake_external with(intervalBetweenRuns='10h', sizeLimit=100000000, distributed=true, parquetRowGroupSize=100000) <| fake_table",
+		".create-or-alter continuous-export fake_export to table fake_external with(intervalBetweenRuns='1h', sizeLimit=100000000, distributed=true, parquetRowGroupSize=100000) <| fake_table | where isnotempty(f1)",
+		".drop continuous-export fake_export",
+	}, f.controlCommands())
+}
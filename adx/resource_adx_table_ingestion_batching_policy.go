@@ -2,11 +2,11 @@ package adx
 
 import (
 	"context"
-	"fmt"
 	"regexp"
 
 	"encoding/json"
 
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
 	"github.com/favoretti/terraform-provider-adx/adx/validate"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -74,7 +74,16 @@ func resourceADXTableIngestionBatchingPolicyCreateUpdate(ctx context.Context, d
 	maxNumberItems := d.Get("max_items").(int)
 	maxRawSizeMb := d.Get("max_raw_size_mb").(int)
 
-	createStatement := fmt.Sprintf(".alter tables (%s) policy ingestionbatching @'{\"MaximumBatchingTimeSpan\": \"%s\",\"MaximumNumberOfItems\": %d, \"MaximumRawDataSizeMB\": %d}'", tableName, maxBatchingTimespan, maxNumberItems, maxRawSizeMb)
+	policyJSON, err := kql.JSON(TableIngestionBatchingPolicy{
+		MaximumBatchingTimeSpan: maxBatchingTimespan,
+		MaximumNumberOfItems:    maxNumberItems,
+		MaximumRawDataSizeMB:    maxRawSizeMb,
+	})
+	if err != nil {
+		return diag.Errorf("error serializing policy ingestionbatching for Table %q (Database %q): %+v", tableName, databaseName, err)
+	}
+
+	createStatement := kql.New(".alter", "tables").IdentifierList(tableName).Keyword("policy", "ingestionbatching").Literal(policyJSON).String()
 
 	if err := createADXPolicy(ctx, d, meta, "table", "ingestionbatching", databaseName, tableName, createStatement); err != nil {
 		return diag.Errorf("%+v", err)
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 type ADXTableIngestionBatchingPolicyTestResource struct{}
@@ -77,3 +78,28 @@ func (this ADXTableIngestionBatchingPolicyTestResource) template(rtc *ResourceTe
 	}
 	`, rtc.DatabaseName, rtc.EntityName)
 }
+
+func TestFakeADXTableIngestionBatchingPolicy_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db").addTable("fake_table", "f1:string")
+	r := newFakeResource(t, f, resourceADXTableIngestionBatchingPolicy())
+
+	config := map[string]interface{}{
+		"database_name":         "fake_db",
+		"table_name":            "fake_table",
+		"max_batching_timespan": "00:05:00",
+		"max_items":             500,
+		"max_raw_size_mb":       1024,
+	}
+	state := r.apply(config)
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|table|fake_table|policy|ingestionbatching", f.endpoint()), state.ID)
+
+	config["max_items"] = 250
+	state = r.apply(config)
+	assert.Equal(t, "250", state.Attributes["max_items"])
+
+	r.checkImport(f)
+
+	r.destroy()
+	assert.Empty(t, f.database("fake_db").policies)
+}
@@ -2,11 +2,11 @@ package adx
 
 import (
 	"context"
-	"fmt"
 	"strconv"
 
 	"encoding/json"
 
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
 	"github.com/favoretti/terraform-provider-adx/adx/validate"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -57,7 +57,7 @@ func resourceADXTableIngestionTimeCreateUpdate(ctx context.Context, d *schema.Re
 
 	enabledString := strconv.FormatBool(enabled)
 
-	createStatement := fmt.Sprintf(".alter table %s policy ingestiontime %s", tableName, enabledString)
+	createStatement := kql.New(".alter", "table").Identifier(tableName).Keyword("policy", "ingestiontime", enabledString).String()
 
 	if err := createADXPolicy(ctx, d, meta, "table", "ingestiontime", databaseName, tableName, createStatement); err != nil {
 		return diag.Errorf("%+v", err)
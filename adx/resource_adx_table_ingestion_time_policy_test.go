@@ -0,0 +1,35 @@
+package adx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXTableIngestionTimePolicy_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db").addTable("fake_table", "f1:string")
+	r := newFakeResource(t, f, resourceADXTableIngestionTimePolicy())
+
+	state := r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"table_name":    "fake_table",
+		"enabled":       true,
+	})
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|table|fake_table|policy|ingestiontime", f.endpoint()), state.ID)
+	assert.Equal(t, "true", state.Attributes["enabled"])
+
+	state = r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"table_name":    "fake_table",
+		"enabled":       false,
+	})
+	assert.Equal(t, "false", state.Attributes["enabled"])
+
+	r.checkImport(f)
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Empty(t, f.database("fake_db").policies)
+}
@@ -6,10 +6,9 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/Azure/azure-kusto-go/kusto"
 	"github.com/Azure/azure-kusto-go/kusto/data/table"
 	"github.com/Azure/azure-kusto-go/kusto/data/value"
-	"github.com/Azure/azure-kusto-go/kusto/unsafe"
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -137,10 +136,14 @@ func resourceADXTableMappingCreateUpdate(ctx context.Context, d *schema.Resource
 	mapping := expandTableMapping(d.Get("mapping").([]interface{}))
 	entityType := "table"
 
-	kStmtOpts := kusto.UnsafeStmt(unsafe.Stmt{Add: true})
-	createStatement := fmt.Sprintf(".create-or-alter table %s ingestion %s mapping '%s' '[%s]'", tableName, strings.ToLower(kind), name, mapping)
+	mappingJSON, err := kql.JSON(mapping)
+	if err != nil {
+		return diag.Errorf("error serializing Mapping %q (Table %q, Database %q): %+v", name, tableName, databaseName, err)
+	}
 
-	_, err = client.Mgmt(ctx, databaseName, kusto.NewStmt("", kStmtOpts).UnsafeAdd(createStatement))
+	createStatement := kql.New(".create-or-alter", "table").Identifier(tableName).Keyword("ingestion", strings.ToLower(kind), "mapping").StringLiteral(name).Literal(mappingJSON).String()
+
+	_, err = executeADXMgmt(ctx, meta, client, databaseName, createStatement)
 	if err != nil {
 		return diag.Errorf("error creating Mapping %q (Table %q, Database %q): %+v", name, tableName, databaseName, err)
 	}
@@ -175,10 +178,9 @@ func resourceADXTableMappingRead(ctx context.Context, d *schema.ResourceData, me
 		return diags
 	}
 
-	kStmtOpts := kusto.UnsafeStmt(unsafe.Stmt{Add: true})
-	showStatement := fmt.Sprintf(".show table %s ingestion %s mapping '%s'", id.Name, strings.ToLower(id.Kind), id.MappingName)
+	showStatement := kql.New(".show", "table").Identifier(id.Name).Keyword("ingestion", strings.ToLower(id.Kind), "mapping").StringLiteral(id.MappingName).String()
 
-	resp, err := client.Mgmt(ctx, id.DatabaseName, kusto.NewStmt("", kStmtOpts).UnsafeAdd(showStatement))
+	resp, err := executeADXMgmt(ctx, meta, client, id.DatabaseName, showStatement)
 	if err != nil {
 		return diag.Errorf("error reading Table %q (Database %q): %+v", id.Name, id.DatabaseName, err)
 	}
@@ -222,33 +224,32 @@ func resourceADXTableMappingDelete(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	deleteStatement := fmt.Sprintf(".drop table %s ingestion %s mapping '%s'", id.Name, strings.ToLower(id.Kind), id.MappingName)
+	deleteStatement := kql.New(".drop", "table").Identifier(id.Name).Keyword("ingestion", strings.ToLower(id.Kind), "mapping").StringLiteral(id.MappingName).String()
 	return deleteADXEntity(ctx, d, meta, clusterConfig, id.DatabaseName, deleteStatement)
 }
 
-func expandTableMapping(input []interface{}) string {
-	if len(input) == 0 {
-		return ""
-	}
-
-	optionalFields := [6]string{"path", "datatype", "transform", "ordinal", "constvalue", "field"}
-
-	// TODO Convert this to use json.Marshall
-	mappings := make([]string, 0)
+func expandTableMapping(input []interface{}) []Mapping {
+	mappings := make([]Mapping, 0, len(input))
 	for _, v := range input {
 		block := v.(map[string]interface{})
-		mapping := fmt.Sprintf(`"column":"%s"`, block["column"].(string))
-		for _, field := range optionalFields {
-			if t, ok := block[field].(string); ok {
-				if len(t) != 0 {
-					mapping = fmt.Sprintf(`%s,"%s":"%s"`, mapping, field, t)
-				}
-			}
-		}
-		mapping = fmt.Sprintf("{%s}", mapping)
-		mappings = append(mappings, mapping)
+		mappings = append(mappings, Mapping{
+			Column:     block["column"].(string),
+			Path:       mappingField(block, "path"),
+			DataType:   mappingField(block, "datatype"),
+			Transform:  mappingField(block, "transform"),
+			Ordinal:    mappingField(block, "ordinal"),
+			ConstValue: mappingField(block, "constvalue"),
+			Field:      mappingField(block, "field"),
+		})
+	}
+	return mappings
+}
+
+func mappingField(block map[string]interface{}, field string) string {
+	if t, ok := block[field].(string); ok {
+		return t
 	}
-	return strings.Join(mappings, ",")
+	return ""
 }
 
 func flattenTableMapping(input string) []interface{} {
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 type ADXTableMappingTestResource struct{}
@@ -167,3 +168,39 @@ func (this ADXTableMappingTestResource) template(rtc *ResourceTestContext[TableM
 	}
 	`, rtc.Label, rtc.DatabaseName)
 }
+
+func TestFakeADXTableMapping_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db").addTable("fake_table", "f1:string,f2:int")
+	r := newFakeResource(t, f, resourceADXTableMapping())
+
+	state := r.apply(map[string]interface{}{
+		"name":          "fake_mapping",
+		"database_name": "fake_db",
+		"table_name":    "fake_table",
+		"kind":          "json",
+		"mapping": []interface{}{
+			map[string]interface{}{"column": "f1", "path": "$.f1", "datatype": "string"},
+		},
+	})
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|table|fake_table|tablemapping|json|fake_mapping", f.endpoint()), state.ID)
+	assert.Equal(t, "1", state.Attributes["mapping.#"])
+
+	state = r.apply(map[string]interface{}{
+		"name":          "fake_mapping",
+		"database_name": "fake_db",
+		"table_name":    "fake_table",
+		"kind":          "json",
+		"mapping": []interface{}{
+			map[string]interface{}{"column": "f1", "path": "$.f1", "datatype": "string"},
+			map[string]interface{}{"column": "f2", "path": "$.nested.f2", "datatype": "int"},
+		},
+	})
+	assert.Equal(t, "2", state.Attributes["mapping.#"])
+	assert.Equal(t, "$.nested.f2", state.Attributes["mapping.1.path"])
+
+	r.checkImport(f)
+
+	r.destroy()
+	assert.Empty(t, f.database("fake_db").tables["fake_table"].mappings)
+}
@@ -2,10 +2,10 @@ package adx
 
 import (
 	"context"
-	"fmt"
 
 	"encoding/json"
 
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
 	"github.com/favoretti/terraform-provider-adx/adx/validate"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -153,12 +153,12 @@ func resourceADXTablePartitioningPolicyCreateUpdate(ctx context.Context, d *sche
 		EffectiveDateTime: effectiveDateTime,
 	}
 
-	policyJson, marshErr := json.Marshal(adxPolicy)
+	policyJson, marshErr := kql.JSON(adxPolicy)
 	if marshErr != nil {
 		return diag.Errorf("%+v", marshErr)
 	}
 
-	createStatement := fmt.Sprintf(".alter table %s policy partitioning ```%s```", tableName, policyJson)
+	createStatement := kql.New(".alter", "table").Identifier(tableName).Keyword("policy", "partitioning").Literal(policyJson).String()
 
 	if err := createADXPolicy(ctx, d, meta, "table", "partitioning", databaseName, tableName, createStatement); err != nil {
 		return diag.Errorf("%+v", err)
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 type ADXTablePartitioningPolicyTestResource struct{}
@@ -124,3 +125,42 @@ func (this ADXTablePartitioningPolicyTestResource) template(rtc *ResourceTestCon
 	}
 	`, rtc.DatabaseName, rtc.EntityName)
 }
+
+func TestFakeADXTablePartitioningPolicy_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db").addTable("fake_table", "f1:string,f2:datetime")
+	r := newFakeResource(t, f, resourceADXTablePartitioningPolicy())
+
+	config := func(maxPartitionCount int) map[string]interface{} {
+		return map[string]interface{}{
+			"database_name":       "fake_db",
+			"table_name":          "fake_table",
+			"effective_date_time": "2022-07-19T13:56:45Z",
+			"partition_key": []interface{}{
+				map[string]interface{}{
+					"column_name": "f1",
+					"kind":        "Hash",
+					"hash_properties": []interface{}{
+						map[string]interface{}{
+							"function":                  "XxHash64",
+							"max_partition_count":       maxPartitionCount,
+							"seed":                      2,
+							"partition_assignment_mode": "Uniform",
+						},
+					},
+				},
+			},
+		}
+	}
+	state := r.apply(config(128))
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|table|fake_table|policy|partitioning", f.endpoint()), state.ID)
+	assert.Equal(t, "128", state.Attributes["partition_key.0.hash_properties.0.max_partition_count"])
+
+	state = r.apply(config(256))
+	assert.Equal(t, "256", state.Attributes["partition_key.0.hash_properties.0.max_partition_count"])
+
+	r.checkImport(f)
+
+	r.destroy()
+	assert.Empty(t, f.database("fake_db").policies)
+}
@@ -2,10 +2,11 @@ package adx
 
 import (
 	"context"
-	"fmt"
+	"strconv"
 
 	"encoding/json"
 
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
 	"github.com/favoretti/terraform-provider-adx/adx/validate"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -13,8 +14,6 @@ import (
 	"time"
 )
 
-
-
 type TableRestrictedViewPolicy struct {
 	IsEnabled *bool
 }
@@ -70,15 +69,13 @@ func resourceADXTableRestrictedViewPolicyCreateUpdate(ctx context.Context, d *sc
 	enabled := d.Get("enabled").(string)
 	followerDatabase := d.Get("follower_database").(bool)
 
-	followerDatabaseClause := ""
+	createStatement := kql.New(".alter")
 	if followerDatabase {
-		followerDatabaseClause = fmt.Sprintf("follower database %s", escapeEntityNameIfRequired(databaseName))
+		createStatement.Keyword("follower", "database").Identifier(databaseName)
 	}
+	createStatement.Keyword("table").Identifier(tableName).Keyword("policy", "restricted_view_access", enabled)
 
-	createStatement := fmt.Sprintf(".alter %s table %s policy restricted_view_access %s",
-		followerDatabaseClause, tableName, enabled)
-
-	if err := createADXPolicy(ctx, d, meta, "table", "restricted_view_access", databaseName, tableName, createStatement); err != nil {
+	if err := createADXPolicy(ctx, d, meta, "table", "restricted_view_access", databaseName, tableName, createStatement.String()); err != nil {
 		return diag.Errorf("%+v", err)
 	}
 
@@ -121,7 +118,8 @@ func resourceADXTableRestrictedViewPolicyRead(ctx context.Context, d *schema.Res
 			return diag.Errorf("invalid object returned for policy restricted_view for table %q (Database %q): %s", id.Name, id.DatabaseName, resultSet[0])
 		}
 
-		d.Set("enabled", policy.IsEnabled)
+		// enabled is a string attribute, setting the bool directly fails and leaves it unread
+		d.Set("enabled", strconv.FormatBool(*policy.IsEnabled))
 		d.Set("table_name", id.Name)
 		d.Set("database_name", id.DatabaseName)
 	}
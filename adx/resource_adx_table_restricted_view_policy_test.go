@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 type ADXTableRestrictedViewPolicyTestResource struct{}
@@ -126,3 +127,30 @@ func (this ADXTableRestrictedViewPolicyTestResource) template(rtc *ResourceTestC
 	}
 	`, rtc.DatabaseName, rtc.EntityName)
 }
+
+func TestFakeADXTableRestrictedViewPolicy_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db").addTable("fake_table", "f1:string")
+	r := newFakeResource(t, f, resourceADXTableRestrictedViewPolicy())
+
+	state := r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"table_name":    "fake_table",
+		"enabled":       "true",
+	})
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|table|fake_table|policy|restricted_view_access", f.endpoint()), state.ID)
+	assert.Equal(t, "true", state.Attributes["enabled"])
+
+	state = r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"table_name":    "fake_table",
+		"enabled":       "false",
+	})
+	assert.Equal(t, "false", state.Attributes["enabled"])
+
+	r.checkImport(f, "follower_database")
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Empty(t, f.database("fake_db").policies)
+}
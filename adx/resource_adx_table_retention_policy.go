@@ -2,11 +2,11 @@ package adx
 
 import (
 	"context"
-	"fmt"
 	"regexp"
 
 	"encoding/json"
 
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
 	"github.com/favoretti/terraform-provider-adx/adx/validate"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -72,7 +72,12 @@ func resourceADXTableRetentionPolicyCreateUpdate(ctx context.Context, d *schema.
 		recoverabilityString = "disabled"
 	}
 
-	createStatement := fmt.Sprintf(".alter-merge table %s policy retention softdelete = %s recoverability = %s", tableName, softDeleteTimespan, recoverabilityString)
+	softDelete, err := kql.Timespan(softDeleteTimespan)
+	if err != nil {
+		return diag.Errorf("%+v", err)
+	}
+
+	createStatement := kql.New(".alter-merge", "table").Identifier(tableName).Keyword("policy", "retention", "softdelete", "=").Literal(softDelete).Keyword("recoverability", "=", recoverabilityString).String()
 
 	if err := createADXPolicy(ctx, d, meta, "table", "retention", databaseName, tableName, createStatement); err != nil {
 		return diag.Errorf("%+v", err)
@@ -0,0 +1,43 @@
+package adx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXTableRetentionPolicy_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db").addTable("fake_table", "f1:string")
+	r := newFakeResource(t, f, resourceADXTableRetentionPolicy())
+
+	state := r.apply(map[string]interface{}{
+		"database_name":      "fake_db",
+		"table_name":         "fake_table",
+		"soft_delete_period": "365d",
+		"recoverability":     true,
+	})
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|table|fake_table|policy|retention", f.endpoint()), state.ID)
+	assert.Equal(t, "365d", state.Attributes["soft_delete_period"])
+	assert.Equal(t, "true", state.Attributes["recoverability"])
+
+	state = r.apply(map[string]interface{}{
+		"database_name":      "fake_db",
+		"table_name":         "fake_table",
+		"soft_delete_period": "12h",
+		"recoverability":     false,
+	})
+	assert.Equal(t, "12h", state.Attributes["soft_delete_period"])
+	assert.Equal(t, "false", state.Attributes["recoverability"])
+
+	r.checkImport(f, "soft_delete_period")
+
+	r.destroy()
+	assert.Empty(t, f.database("fake_db").policies)
+	assert.Equal(t, []string{
+		".alter-merge table fake_table policy retention softdelete = 365d recoverability = enabled",
+		".alter-merge table fake_table policy retention softdelete = 12h recoverability = disabled",
+		".delete table fake_table policy retention",
+	}, f.controlCommands())
+}
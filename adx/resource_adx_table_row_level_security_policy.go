@@ -2,11 +2,10 @@ package adx
 
 import (
 	"context"
-	"fmt"
-	"strings"
 
 	"encoding/json"
 
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
 	"github.com/favoretti/terraform-provider-adx/adx/validate"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -76,18 +75,13 @@ func resourceADXTableRowLevelSecurityPolicyCreateUpdate(ctx context.Context, d *
 		enabledString = "disable"
 	}
 
-	var withParams []string
+	withParams := kql.NewProperties()
 
 	if allowMVWithoutRLS, ok := d.GetOk("allow_mv_without_rls"); ok {
-		withParams = append(withParams, fmt.Sprintf("allowMaterializedViewsWithoutRowLevelSecurity=%t", allowMVWithoutRLS.(bool)))
+		withParams.Bool("allowMaterializedViewsWithoutRowLevelSecurity", allowMVWithoutRLS.(bool))
 	}
 
-	withClause := ""
-	if len(withParams) > 0 {
-		withClause = fmt.Sprintf("with(%s)", strings.Join(withParams, ", "))
-	}
-
-	createStatement := fmt.Sprintf(".alter table %s policy row_level_security %s %s \"%s\"", tableName, enabledString, withClause, query)
+	createStatement := kql.New(".alter", "table").Identifier(tableName).Keyword("policy", "row_level_security", enabledString).With(withParams).StringLiteral(query).String()
 
 	if err := createADXPolicy(ctx, d, meta, "table", "row_level_security", databaseName, tableName, createStatement); err != nil {
 		return diag.Errorf("%+v", err)
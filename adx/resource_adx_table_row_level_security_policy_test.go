@@ -0,0 +1,40 @@
+package adx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXTableRowLevelSecurityPolicy_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db").addTable("fake_table", "f1:string")
+	r := newFakeResource(t, f, resourceADXTableRowLevelSecurityPolicy())
+
+	state := r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"table_name":    "fake_table",
+		"query":         "fake_table | where f1 == 'visible'",
+	})
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|table|fake_table|policy|row_level_security", f.endpoint()), state.ID)
+	assert.Equal(t, "true", state.Attributes["enabled"])
+
+	state = r.apply(map[string]interface{}{
+		"database_name":        "fake_db",
+		"table_name":           "fake_table",
+		"query":                "fake_table | where f1 != 'hidden'",
+		"allow_mv_without_rls": true,
+	})
+	assert.Equal(t, "fake_table | where f1 != 'hidden'", state.Attributes["query"])
+
+	r.checkImport(f, "allow_mv_without_rls")
+
+	r.destroy()
+	assert.Empty(t, f.database("fake_db").policies)
+	assert.Equal(t, []string{
+		".alter table fake_table policy row_level_security enable 'fake_table | where f1 == \\'visible\\''",
+		".alter table fake_table policy row_level_security enable with(allowMaterializedViewsWithoutRowLevelSecurity=true) 'fake_table | where f1 != \\'hidden\\''",
+		".delete table fake_table policy row_level_security",
+	}, f.controlCommands())
+}
@@ -0,0 +1,154 @@
+package adx
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
+	"github.com/favoretti/terraform-provider-adx/adx/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type RowOrderPolicy struct {
+	KeyColumns []string `json:"KeyColumns"`
+}
+
+func resourceADXTableRowOrderPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceADXTableRowOrderPolicyCreateUpdate,
+		ReadContext:   resourceADXTableRowOrderPolicyRead,
+		UpdateContext: resourceADXTableRowOrderPolicyCreateUpdate,
+		DeleteContext: resourceADXTableRowOrderPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster": getClusterConfigInputSchema(),
+			"database_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+			},
+
+			"entity_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          "table",
+				ValidateDiagFunc: validate.StringInSlice([]string{"table", "materialized_view"}),
+			},
+
+			"entity_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+			},
+
+			"key": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Ordered list of the columns rows are sorted by within each extent",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"column_name": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validate.StringIsNotEmpty,
+						},
+						"direction": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "asc",
+							ValidateDiagFunc: validate.StringInSlice([]string{"asc", "desc"}),
+						},
+					},
+				},
+			},
+		},
+		CustomizeDiff: clusterConfigCustomDiff,
+	}
+}
+
+func resourceADXTableRowOrderPolicyCreateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	databaseName := d.Get("database_name").(string)
+	kustoEntityType := mergePolicyToKustoEntityType(d.Get("entity_type").(string))
+	entityName := d.Get("entity_name").(string)
+
+	var keys []string
+	for _, v := range d.Get("key").([]interface{}) {
+		key := v.(map[string]interface{})
+		keys = append(keys, kql.Identifier(key["column_name"].(string))+" "+key["direction"].(string))
+	}
+
+	createStatement := kql.New(".alter", kustoEntityType).Identifier(entityName).Keyword("policy", "roworder").Literal("(" + strings.Join(keys, ", ") + ")").String()
+
+	if diags := createADXPolicy(ctx, d, meta, kustoEntityType, "roworder", databaseName, entityName, createStatement); diags != nil {
+		return diags
+	}
+
+	return resourceADXTableRowOrderPolicyRead(ctx, d, meta)
+}
+
+func resourceADXTableRowOrderPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id, err := parseADXPolicyID(d.Id())
+	if err != nil {
+		return diag.Errorf("error parsing row order policy ID: %+v", err)
+	}
+
+	_, resultSet, diags := readADXPolicy(ctx, d, meta, id.EntityType, "roworder")
+	if diags.HasError() || resultSet == nil || len(resultSet) == 0 {
+		return diags
+	}
+
+	if resultSet[0].Policy == "null" || resultSet[0].Policy == "" {
+		d.SetId("")
+		return diags
+	}
+
+	var policy RowOrderPolicy
+	if err := json.Unmarshal([]byte(resultSet[0].Policy), &policy); err != nil {
+		return diag.Errorf("error parsing row order policy for %s %q (Database %q): %+v", id.EntityType, id.Name, id.DatabaseName, err)
+	}
+
+	d.Set("database_name", id.DatabaseName)
+	d.Set("entity_type", mergePolicyFromKustoEntityType(id.EntityType))
+	d.Set("entity_name", id.Name)
+	d.Set("key", flattenRowOrderKeys(policy.KeyColumns))
+
+	return diags
+}
+
+func resourceADXTableRowOrderPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id, err := parseADXPolicyID(d.Id())
+	if err != nil {
+		return diag.Errorf("error parsing row order policy ID: %+v", err)
+	}
+
+	return deleteADXPolicy(ctx, d, meta, id.EntityType, "roworder")
+}
+
+// flattenRowOrderKeys splits the key columns of the policy, such as "Timestamp desc", into column and direction
+func flattenRowOrderKeys(keyColumns []string) []interface{} {
+	keys := make([]interface{}, 0, len(keyColumns))
+	for _, keyColumn := range keyColumns {
+		keyColumn = strings.TrimSpace(keyColumn)
+		columnName, direction := keyColumn, "asc"
+		if i := strings.LastIndex(keyColumn, " "); i > 0 {
+			switch strings.ToLower(keyColumn[i+1:]) {
+			case "asc", "desc":
+				columnName, direction = strings.TrimSpace(keyColumn[:i]), strings.ToLower(keyColumn[i+1:])
+			}
+		}
+		keys = append(keys, map[string]interface{}{
+			"column_name": kql.UnquoteIdentifier(columnName),
+			"direction":   direction,
+		})
+	}
+	return keys
+}
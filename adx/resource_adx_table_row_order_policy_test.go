@@ -0,0 +1,78 @@
+package adx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXTableRowOrderPolicy_table(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db").addTable("fake_table", "TenantId:string,Timestamp:datetime,['Event Name']:string")
+	r := newFakeResource(t, f, resourceADXTableRowOrderPolicy())
+
+	config := map[string]interface{}{
+		"database_name": "fake_db",
+		"entity_name":   "fake_table",
+		"key": []interface{}{
+			map[string]interface{}{"column_name": "TenantId"},
+			map[string]interface{}{"column_name": "Timestamp", "direction": "desc"},
+		},
+	}
+	state := r.apply(config)
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|table|fake_table|policy|roworder", f.endpoint()), state.ID)
+	assert.Equal(t, "2", state.Attributes["key.#"])
+	assert.Equal(t, "TenantId", state.Attributes["key.0.column_name"])
+	assert.Equal(t, "asc", state.Attributes["key.0.direction"])
+	assert.Equal(t, "desc", state.Attributes["key.1.direction"])
+
+	config["key"] = []interface{}{
+		map[string]interface{}{"column_name": "Event Name", "direction": "desc"},
+	}
+	state = r.apply(config)
+	assert.Equal(t, "Event Name", state.Attributes["key.0.column_name"])
+
+	r.checkImport(f)
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Empty(t, f.database("fake_db").policies)
+	assert.Equal(t, []string{
+		".alter table fake_table policy roworder (TenantId asc, Timestamp desc)",
+		".alter table fake_table policy roworder (['Event Name'] desc)",
+		".delete table fake_table policy roworder",
+	}, f.controlCommands())
+}
+
+func TestFakeADXTableRowOrderPolicy_materializedView(t *testing.T) {
+	f := newFakeKusto(t)
+	db := f.database("fake_db")
+	db.addTable("fake_table", "f1:string")
+	db.addMaterializedView("fake_view", "fake_table", "fake_table | summarize count() by f1")
+	r := newFakeResource(t, f, resourceADXTableRowOrderPolicy())
+
+	state := r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"entity_type":   "materialized_view",
+		"entity_name":   "fake_view",
+		"key": []interface{}{
+			map[string]interface{}{"column_name": "f1"},
+		},
+	})
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|materialized-view|fake_view|policy|roworder", f.endpoint()), state.ID)
+
+	r.checkImport(f)
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Empty(t, db.policies)
+}
+
+func TestADXTableRowOrderPolicy_flattenRowOrderKeys(t *testing.T) {
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"column_name": "TenantId", "direction": "asc"},
+		map[string]interface{}{"column_name": "Timestamp", "direction": "desc"},
+		map[string]interface{}{"column_name": "Event Name", "direction": "asc"},
+	}, flattenRowOrderKeys([]string{"TenantId ASC", "Timestamp desc", "['Event Name']"}))
+}
@@ -0,0 +1,44 @@
+package adx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXTableSecurityRole_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db").addTable("fake_table", "f1:string")
+	r := newFakeResource(t, f, resourceADXTableSecurityRole())
+
+	config := map[string]interface{}{
+		"database_name": "fake_db",
+		"table_name":    "fake_table",
+		"role":          "admins",
+		"principal_fqn": "aaduser=user@example.com",
+		"notes":         "fake admin",
+	}
+	state := r.apply(config)
+	resolvedFQN := resolvePrincipalFQN("aaduser=user@example.com")
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|table|fake_table|security_role|admins|%s", f.endpoint(), resolvedFQN), state.ID)
+	assert.Equal(t, resolvedFQN, state.Attributes["principal_fqn"])
+	assert.Equal(t, "AAD User", state.Attributes["principal_type"])
+	assert.Equal(t, "Fake Principal (upn: user@example.com)", state.Attributes["principal_display_name"])
+
+	config["notes"] = "updated notes"
+	state = r.apply(config)
+	assert.Equal(t, "updated notes", state.Attributes["notes"])
+
+	r.checkImport(f)
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Empty(t, f.database("fake_db").tables["fake_table"].principals)
+	assert.Equal(t, []string{
+		".add table fake_table admins ('aaduser=user@example.com') 'fake admin'",
+		fmt.Sprintf(".drop table fake_table admins ('%s')", resolvedFQN),
+		fmt.Sprintf(".add table fake_table admins ('%s') 'updated notes'", resolvedFQN),
+		fmt.Sprintf(".drop table fake_table admins ('%s')", resolvedFQN),
+	}, f.controlCommands())
+}
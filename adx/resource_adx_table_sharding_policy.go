@@ -0,0 +1,140 @@
+package adx
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
+	"github.com/favoretti/terraform-provider-adx/adx/validate"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type ShardingPolicy struct {
+	MaxRowCount         int `json:"MaxRowCount"`
+	MaxExtentSizeInMb   int `json:"MaxExtentSizeInMb"`
+	MaxOriginalSizeInMb int `json:"MaxOriginalSizeInMb"`
+}
+
+func resourceADXTableShardingPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceADXTableShardingPolicyCreateUpdate,
+		ReadContext:   resourceADXTableShardingPolicyRead,
+		UpdateContext: resourceADXTableShardingPolicyCreateUpdate,
+		DeleteContext: resourceADXTableShardingPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster": getClusterConfigInputSchema(),
+			"database_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+			},
+
+			"entity_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          "table",
+				ValidateDiagFunc: validate.StringInSlice([]string{"table", "materialized_view"}),
+			},
+
+			"entity_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringIsNotEmpty,
+			},
+
+			"max_row_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1048576,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"max_extent_size_in_mb": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      8192,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"max_original_size_in_mb": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3072,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+		},
+		CustomizeDiff: clusterConfigCustomDiff,
+	}
+}
+
+func resourceADXTableShardingPolicyCreateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	databaseName := d.Get("database_name").(string)
+	kustoEntityType := mergePolicyToKustoEntityType(d.Get("entity_type").(string))
+	entityName := d.Get("entity_name").(string)
+
+	policyJSON, err := kql.JSON(ShardingPolicy{
+		MaxRowCount:         d.Get("max_row_count").(int),
+		MaxExtentSizeInMb:   d.Get("max_extent_size_in_mb").(int),
+		MaxOriginalSizeInMb: d.Get("max_original_size_in_mb").(int),
+	})
+	if err != nil {
+		return diag.Errorf("error serializing sharding policy: %+v", err)
+	}
+
+	createStatement := kql.New(".alter", kustoEntityType).Identifier(entityName).Keyword("policy", "sharding").Literal(policyJSON).String()
+
+	if diags := createADXPolicy(ctx, d, meta, kustoEntityType, "sharding", databaseName, entityName, createStatement); diags != nil {
+		return diags
+	}
+
+	return resourceADXTableShardingPolicyRead(ctx, d, meta)
+}
+
+func resourceADXTableShardingPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id, err := parseADXPolicyID(d.Id())
+	if err != nil {
+		return diag.Errorf("error parsing sharding policy ID: %+v", err)
+	}
+
+	_, resultSet, diags := readADXPolicy(ctx, d, meta, id.EntityType, "sharding")
+	if diags.HasError() || resultSet == nil || len(resultSet) == 0 {
+		return diags
+	}
+
+	if resultSet[0].Policy == "null" || resultSet[0].Policy == "" {
+		d.SetId("")
+		return diags
+	}
+
+	var policy ShardingPolicy
+	if err := json.Unmarshal([]byte(resultSet[0].Policy), &policy); err != nil {
+		return diag.Errorf("error parsing sharding policy for %s %q (Database %q): %+v", id.EntityType, id.Name, id.DatabaseName, err)
+	}
+
+	d.Set("database_name", id.DatabaseName)
+	d.Set("entity_type", mergePolicyFromKustoEntityType(id.EntityType))
+	d.Set("entity_name", id.Name)
+	d.Set("max_row_count", policy.MaxRowCount)
+	d.Set("max_extent_size_in_mb", policy.MaxExtentSizeInMb)
+	d.Set("max_original_size_in_mb", policy.MaxOriginalSizeInMb)
+
+	return diags
+}
+
+func resourceADXTableShardingPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id, err := parseADXPolicyID(d.Id())
+	if err != nil {
+		return diag.Errorf("error parsing sharding policy ID: %+v", err)
+	}
+
+	return deleteADXPolicy(ctx, d, meta, id.EntityType, "sharding")
+}
@@ -0,0 +1,60 @@
+package adx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXTableShardingPolicy_table(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db").addTable("fake_table", "f1:string")
+	r := newFakeResource(t, f, resourceADXTableShardingPolicy())
+
+	config := map[string]interface{}{
+		"database_name": "fake_db",
+		"entity_name":   "fake_table",
+	}
+	state := r.apply(config)
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|table|fake_table|policy|sharding", f.endpoint()), state.ID)
+	assert.Equal(t, "1048576", state.Attributes["max_row_count"])
+
+	config["max_row_count"] = 750000
+	config["max_extent_size_in_mb"] = 4096
+	state = r.apply(config)
+	assert.Equal(t, "750000", state.Attributes["max_row_count"])
+	assert.JSONEq(t, `{"MaxRowCount":750000,"MaxExtentSizeInMb":4096,"MaxOriginalSizeInMb":3072}`, f.database("fake_db").policies["table|fake_table|sharding"])
+
+	r.checkImport(f)
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Empty(t, f.database("fake_db").policies)
+}
+
+func TestFakeADXTableShardingPolicy_materializedView(t *testing.T) {
+	f := newFakeKusto(t)
+	db := f.database("fake_db")
+	db.addTable("fake_table", "f1:string")
+	db.addMaterializedView("fake_view", "fake_table", "fake_table | summarize count() by f1")
+	r := newFakeResource(t, f, resourceADXTableShardingPolicy())
+
+	state := r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"entity_type":   "materialized_view",
+		"entity_name":   "fake_view",
+		"max_row_count": 500000,
+	})
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|materialized-view|fake_view|policy|sharding", f.endpoint()), state.ID)
+	assert.Equal(t, "materialized_view", state.Attributes["entity_type"])
+
+	r.checkImport(f)
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Equal(t, []string{
+		`.alter materialized-view fake_view policy sharding '{"MaxRowCount":500000,"MaxExtentSizeInMb":8192,"MaxOriginalSizeInMb":3072}'`,
+		".delete materialized-view fake_view policy sharding",
+	}, f.controlCommands())
+}
@@ -2,11 +2,11 @@ package adx
 
 import (
 	"context"
-	"fmt"
 	"strconv"
 
 	"encoding/json"
 
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
 	"github.com/favoretti/terraform-provider-adx/adx/validate"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -74,14 +74,22 @@ func resourceADXTableStreamingIngestionPolicyCreateUpdate(ctx context.Context, d
 	databaseName := d.Get("database_name").(string)
 	enabled := d.Get("enabled").(bool)
 
-	hintAllocatedRateString := "null"
+	// HintAllocatedRate is sent as null when it is not set
+	policy := struct {
+		IsEnabled         bool
+		HintAllocatedRate *string
+	}{IsEnabled: enabled}
 	if hintAllocatedRate, ok := d.GetOk("hint_allocated_rate"); ok {
-		hintAllocatedRateString = fmt.Sprintf("\"%s\"", hintAllocatedRate)
+		hint := hintAllocatedRate.(string)
+		policy.HintAllocatedRate = &hint
 	}
 
-	enabledString := strconv.FormatBool(enabled)
+	policyJSON, err := kql.JSON(policy)
+	if err != nil {
+		return diag.Errorf("error serializing policy streamingingestion for Table %q (Database %q): %+v", tableName, databaseName, err)
+	}
 
-	createStatement := fmt.Sprintf(".alter table %s policy streamingingestion '{\"IsEnabled\": %s, \"HintAllocatedRate\": %s}'", tableName, enabledString, hintAllocatedRateString)
+	createStatement := kql.New(".alter", "table").Identifier(tableName).Keyword("policy", "streamingingestion").Literal(policyJSON).String()
 
 	if err := createADXPolicy(ctx, d, meta, "table", "streamingingestion", databaseName, tableName, createStatement); err != nil {
 		return diag.Errorf("%+v", err)
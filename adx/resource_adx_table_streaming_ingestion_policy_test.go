@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 type ADXTableStreamingIngestionPolicyTestResource struct{}
@@ -109,3 +110,31 @@ func (this ADXTableStreamingIngestionPolicyTestResource) template(rtc *ResourceT
 	}
 	`, rtc.DatabaseName, rtc.EntityName)
 }
+
+func TestFakeADXTableStreamingIngestionPolicy_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db").addTable("fake_table", "f1:string")
+	r := newFakeResource(t, f, resourceADXTableStreamingIngestionPolicy())
+
+	state := r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"table_name":    "fake_table",
+		"enabled":       true,
+	})
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|table|fake_table|policy|streamingingestion", f.endpoint()), state.ID)
+	assert.Equal(t, "true", state.Attributes["enabled"])
+
+	state = r.apply(map[string]interface{}{
+		"database_name":       "fake_db",
+		"table_name":          "fake_table",
+		"enabled":             true,
+		"hint_allocated_rate": "2.5",
+	})
+	assert.Equal(t, "2.5", state.Attributes["hint_allocated_rate"])
+
+	r.checkImport(f)
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Empty(t, f.database("fake_db").policies)
+}
@@ -184,4 +184,71 @@ func TestADXTable_unescapeTableSchema(t *testing.T) {
 
 	name = unescapeTableSchema("name:string, ['ename']:int,othername:string")
 	assert.Equal(t, "name:string, ename:int,othername:string", name, "ename should have had adx escaping removed")
+
+	name = unescapeTableSchema("['my col']:string, ['a, b']:int,['table']:long,[\"x:y\"]:string")
+	assert.Equal(t, "my col:string, a, b:int,table:long,x:y:string", name, "quoted names with spaces, commas, keywords and colons should have been unescaped")
+}
+
+func TestFakeADXTable_quotedNames(t *testing.T) {
+	f := newFakeKusto(t)
+	r := newFakeResource(t, f, resourceADXTable())
+
+	state := r.apply(map[string]interface{}{
+		"name":          "fake table",
+		"database_name": "fake_db",
+		"column": []interface{}{
+			map[string]interface{}{"name": "my col", "type": "string"},
+			map[string]interface{}{"name": "table", "type": "int"},
+			map[string]interface{}{"name": "1st", "type": "long"},
+			map[string]interface{}{"name": "f1", "type": "string"},
+		},
+	})
+	assert.Equal(t, "fake table", state.Attributes["name"])
+	assert.Equal(t, "my col", state.Attributes["column.0.name"])
+	assert.Equal(t, "table", state.Attributes["column.1.name"])
+	assert.Equal(t, "1st", state.Attributes["column.2.name"])
+
+	r.checkImport(f, "merge_on_update")
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Equal(t, []string{
+		".create table ['fake table'] (['my col']:string,['table']:int,['1st']:long,f1:string)",
+		".drop table ['fake table']",
+	}, f.controlCommands())
+}
+
+func TestFakeADXTable_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	r := newFakeResource(t, f, resourceADXTable())
+
+	state := r.apply(map[string]interface{}{
+		"name":          "fake_table",
+		"database_name": "fake_db",
+		"table_schema":  "f1:string,f2:string,f3:int",
+		"folder":        "fake",
+	})
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|table|fake_table", f.endpoint()), state.ID)
+	assert.Equal(t, "f1:string,f2:string,f3:int", state.Attributes["table_schema"])
+	assert.Equal(t, "fake", state.Attributes["folder"])
+
+	state = r.apply(map[string]interface{}{
+		"name":            "fake_table",
+		"database_name":   "fake_db",
+		"table_schema":    "f1:string,f2:string,f3:int,f4:string",
+		"folder":          "fake",
+		"merge_on_update": true,
+	})
+	assert.Equal(t, "f1:string,f2:string,f3:int,f4:string", state.Attributes["table_schema"])
+	assert.Equal(t, "f4", state.Attributes["column.3.name"])
+
+	r.checkImport(f, "merge_on_update")
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Equal(t, []string{
+		".create table fake_table (f1:string,f2:string,f3:int) with(folder='fake')",
+		".alter-merge table fake_table (f1:string,f2:string,f3:int,f4:string) with(folder='fake')",
+		".drop table fake_table",
+	}, f.controlCommands())
 }
@@ -2,10 +2,10 @@ package adx
 
 import (
 	"context"
-	"fmt"
 
 	"encoding/json"
 
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
 	"github.com/favoretti/terraform-provider-adx/adx/validate"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -17,7 +17,7 @@ type TableUpdatePolicy struct {
 	Query                        string
 	IsTransactional              bool
 	PropagateIngestionProperties bool
-	ManagedIdentity              string
+	ManagedIdentity              string `json:",omitempty"`
 }
 
 func resourceADXTableUpdatePolicy() *schema.Resource {
@@ -94,13 +94,20 @@ func resourceADXTableUpdatePolicyCreateUpdate(ctx context.Context, d *schema.Res
 	transactional := d.Get("transactional").(bool)
 	propagateIngestionProperties := d.Get("propagate_ingestion_properties").(bool)
 
-	createStatement := fmt.Sprintf(".alter table %s policy update @'[{\"IsEnabled\": %t, \"Source\": \"%s\", \"Query\": \"%s\", \"IsTransactional\": %t, \"PropagateIngestionProperties\": %t}]'", tableName, enabled, sourceTable, query, transactional, propagateIngestionProperties)
-	
-	if len(d.Get("managed_identity").(string)) > 0 {
-		managedIdentity := d.Get("managed_identity").(string)
-		createStatement = fmt.Sprintf(".alter table %s policy update @'[{\"IsEnabled\": %t, \"Source\": \"%s\", \"Query\": \"%s\", \"IsTransactional\": %t, \"PropagateIngestionProperties\": %t, \"ManagedIdentity\": \"%s\"}]'", tableName, enabled, sourceTable, query, transactional, propagateIngestionProperties, managedIdentity)
+	policyJSON, err := kql.JSON([]TableUpdatePolicy{{
+		IsEnabled:                    enabled,
+		Source:                       sourceTable,
+		Query:                        query,
+		IsTransactional:              transactional,
+		PropagateIngestionProperties: propagateIngestionProperties,
+		ManagedIdentity:              d.Get("managed_identity").(string),
+	}})
+	if err != nil {
+		return diag.Errorf("error serializing policy update for Table %q (Database %q): %+v", tableName, databaseName, err)
 	}
 
+	createStatement := kql.New(".alter", "table").Identifier(tableName).Keyword("policy", "update").Literal(policyJSON).String()
+
 	if err := createADXPolicy(ctx, d, meta, "table", "update", databaseName, tableName, createStatement); err != nil {
 		return diag.Errorf("%+v", err)
 	}
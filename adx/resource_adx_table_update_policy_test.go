@@ -0,0 +1,42 @@
+package adx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeADXTableUpdatePolicy_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db").addTable("fake_source", "f1:string")
+	f.database("fake_db").addTable("fake_table", "f1:string")
+	r := newFakeResource(t, f, resourceADXTableUpdatePolicy())
+
+	config := map[string]interface{}{
+		"database_name": "fake_db",
+		"table_name":    "fake_table",
+		"source_table":  "fake_source",
+		"query":         "fake_source | project f1",
+		"transactional": true,
+	}
+	state := r.apply(config)
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|table|fake_table|policy|update", f.endpoint()), state.ID)
+	assert.Equal(t, "true", state.Attributes["transactional"])
+
+	config["query"] = "fake_source | where isnotempty(f1)"
+	config["transactional"] = false
+	state = r.apply(config)
+	assert.Equal(t, "fake_source | where isnotempty(f1)", state.Attributes["query"])
+	assert.Equal(t, "false", state.Attributes["transactional"])
+
+	config["query"] = `fake_source | where f1 != "it's" and f1 !has @'c:\temp'`
+	state = r.apply(config)
+	assert.Equal(t, `fake_source | where f1 != "it's" and f1 !has @'c:\temp'`, state.Attributes["query"])
+
+	r.checkImport(f)
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Empty(t, f.database("fake_db").policies)
+}
@@ -3,12 +3,9 @@ package adx
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"reflect"
-	"strings"
 
-	"github.com/Azure/azure-kusto-go/kusto"
-	"github.com/Azure/azure-kusto-go/kusto/unsafe"
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
 	"github.com/favoretti/terraform-provider-adx/adx/validate"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -90,18 +87,19 @@ func resourceADXWorkloadGroupCreateUpdate(ctx context.Context, d *schema.Resourc
 	name := d.Get("name").(string)
 	databaseName := d.Get("database_name").(string)
 
-	policyObject := buildWorkloadGroupPolicyObject(d)
+	policyObject, err := kql.JSON(buildWorkloadGroupPolicyObject(d))
+	if err != nil {
+		return diag.Errorf("error serializing workload group %q (Database %q): %+v", name, databaseName, err)
+	}
 
-	escapedName := escapeEntityNameIfRequired(name)
-	createStatement := fmt.Sprintf(".create-or-alter workload_group %s ```\n%s\n```", escapedName, policyObject)
+	createStatement := kql.New(".create-or-alter", "workload_group").Identifier(name).Literal(policyObject).String()
 
 	client, err := getADXClient(meta, clusterConfig)
 	if err != nil {
 		return diag.Errorf("error creating adx client connection: %+v", err)
 	}
 
-	kStmtOpts := kusto.UnsafeStmt(unsafe.Stmt{Add: true})
-	_, err = client.Mgmt(ctx, databaseName, kusto.NewStmt("", kStmtOpts).UnsafeAdd(createStatement))
+	_, err = executeADXMgmt(ctx, meta, client, databaseName, createStatement)
 	if err != nil {
 		return diag.Errorf("error creating/updating workload group %q (Database %q): %+v", name, databaseName, err)
 	}
@@ -119,7 +117,7 @@ func resourceADXWorkloadGroupRead(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
-	showCommand := fmt.Sprintf(".show workload_group %s", escapeEntityNameIfRequired(id.Name))
+	showCommand := kql.New(".show", "workload_group").Identifier(id.Name).String()
 	resultSet, diags := readADXEntity[ADXWorkloadGroup](ctx, meta, clusterConfig, id, showCommand, "workload_group")
 	if diags.HasError() {
 		return diags
@@ -146,7 +144,7 @@ func resourceADXWorkloadGroupDelete(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	deleteStatement := fmt.Sprintf(".drop workload_group %s", escapeEntityNameIfRequired(id.Name))
+	deleteStatement := kql.New(".drop", "workload_group").Identifier(id.Name).String()
 	return deleteADXEntity(ctx, d, meta, clusterConfig, id.DatabaseName, deleteStatement)
 }
 
@@ -154,26 +152,26 @@ func parseADXWorkloadGroupID(input string) (*adxResourceId, error) {
 	return parseADXResourceID(input, 4, 0, 1, 2, 3)
 }
 
-func buildWorkloadGroupPolicyObject(d *schema.ResourceData) string {
-	var parts []string
+func buildWorkloadGroupPolicyObject(d *schema.ResourceData) map[string]json.RawMessage {
+	policies := make(map[string]json.RawMessage)
 
 	if v, ok := d.GetOk("request_limits_policy"); ok {
-		parts = append(parts, fmt.Sprintf(`"RequestLimitsPolicy": %s`, normalizeJSON(v.(string))))
+		policies["RequestLimitsPolicy"] = json.RawMessage(normalizeJSON(v.(string)))
 	}
 	if v, ok := d.GetOk("request_rate_limit_policies"); ok {
-		parts = append(parts, fmt.Sprintf(`"RequestRateLimitPolicies": %s`, normalizeJSON(v.(string))))
+		policies["RequestRateLimitPolicies"] = json.RawMessage(normalizeJSON(v.(string)))
 	}
 	if v, ok := d.GetOk("request_rate_limits_enforcement_policy"); ok {
-		parts = append(parts, fmt.Sprintf(`"RequestRateLimitsEnforcementPolicy": %s`, normalizeJSON(v.(string))))
+		policies["RequestRateLimitsEnforcementPolicy"] = json.RawMessage(normalizeJSON(v.(string)))
 	}
 	if v, ok := d.GetOk("request_queuing_policy"); ok {
-		parts = append(parts, fmt.Sprintf(`"RequestQueuingPolicy": %s`, normalizeJSON(v.(string))))
+		policies["RequestQueuingPolicy"] = json.RawMessage(normalizeJSON(v.(string)))
 	}
 	if v, ok := d.GetOk("query_consistency_policy"); ok {
-		parts = append(parts, fmt.Sprintf(`"QueryConsistencyPolicy": %s`, normalizeJSON(v.(string))))
+		policies["QueryConsistencyPolicy"] = json.RawMessage(normalizeJSON(v.(string)))
 	}
 
-	return fmt.Sprintf("{%s}", strings.Join(parts, ", "))
+	return policies
 }
 
 func flattenWorkloadGroupPolicies(d *schema.ResourceData, workloadGroupJSON string) {
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 type ADXWorkloadGroupTestResource struct{}
@@ -221,3 +222,30 @@ func (this ADXWorkloadGroupTestResource) full(rtc *ResourceTestContext[ADXWorklo
 	}
 	`, rtc.Type, rtc.Label, rtc.DatabaseName, rtc.EntityName)
 }
+
+func TestFakeADXWorkloadGroup_basic(t *testing.T) {
+	f := newFakeKusto(t)
+	r := newFakeResource(t, f, resourceADXWorkloadGroup())
+
+	state := r.apply(map[string]interface{}{
+		"database_name":               "fake_db",
+		"name":                        "fake_group",
+		"request_rate_limit_policies": `[{"IsEnabled":true,"Scope":"WorkloadGroup","LimitKind":"ConcurrentRequests","Properties":{"MaxConcurrentRequests":100}}]`,
+	})
+	assert.Equal(t, fmt.Sprintf("%s|fake_db|workload_group|fake_group", f.endpoint()), state.ID)
+
+	state = r.apply(map[string]interface{}{
+		"database_name":               "fake_db",
+		"name":                        "fake_group",
+		"request_rate_limit_policies": `[{"IsEnabled":true,"Scope":"WorkloadGroup","LimitKind":"ConcurrentRequests","Properties":{"MaxConcurrentRequests":50}}]`,
+		"query_consistency_policy":    `{"QueryConsistency":{"IsRelaxable":true,"Value":"Weak"}}`,
+	})
+	assert.Contains(t, state.Attributes["request_rate_limit_policies"], `"MaxConcurrentRequests":50`)
+	assert.Contains(t, f.cluster.workloadGroups["fake_group"], `"QueryConsistency"`)
+
+	r.checkImport(f)
+
+	r.destroy()
+	r.checkDestroyed()
+	assert.Empty(t, f.cluster.workloadGroups)
+}
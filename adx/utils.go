@@ -6,21 +6,23 @@ import (
 	_ "crypto/md5"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/Azure/azure-kusto-go/kusto"
 	"github.com/Azure/azure-kusto-go/kusto/data/table"
 	"github.com/Azure/azure-kusto-go/kusto/data/value"
-	"github.com/Azure/azure-kusto-go/kusto/unsafe"
-
-	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// newKustoClient is swapped out in tests to connect to a fake cluster
+var newKustoClient = kusto.New
+
 type adxResourceId struct {
 	EndpointURI  string
 	Name         string
@@ -101,8 +103,7 @@ func queryADXMgmt(ctx context.Context, meta interface{}, clusterConfig *ClusterC
 		return nil, err
 	}
 
-	kStmtOpts := kusto.UnsafeStmt(unsafe.Stmt{Add: true})
-	resp, err := client.Mgmt(ctx, databaseName, kusto.NewStmt("", kStmtOpts).UnsafeAdd(query))
+	resp, err := executeADXMgmt(ctx, meta, client, databaseName, query)
 	if err != nil {
 		return nil, fmt.Errorf("error executing adx mgmt query(%s) database(%q): %+v", query, databaseName, err)
 	}
@@ -115,8 +116,7 @@ func queryADX(ctx context.Context, meta interface{}, clusterConfig *ClusterConfi
 		return nil, err
 	}
 
-	kStmtOpts := kusto.UnsafeStmt(unsafe.Stmt{Add: true})
-	resp, err := client.Query(ctx, databaseName, kusto.NewStmt("", kStmtOpts).UnsafeAdd(query))
+	resp, err := executeADXQuery(ctx, meta, client, databaseName, query)
 	if err != nil {
 		return nil, fmt.Errorf("error executing adx query(%s) database(%q): %+v", query, databaseName, err)
 	}
@@ -136,21 +136,16 @@ func deleteADXEntity(ctx context.Context, d *schema.ResourceData, meta interface
 }
 
 func buildADXClient(clusterConfig *ClusterConfig) (*kusto.Client, error) {
-	if len(clusterConfig.ClientID) == 0 {
-		return nil, fmt.Errorf("client_id is required either in the resource or provider config")
-	}
-	if len(clusterConfig.ClientSecret) == 0 {
-		return nil, fmt.Errorf("client_secret is required either in the resource or provider config")
-	}
-	if len(clusterConfig.TenantID) == 0 {
-		return nil, fmt.Errorf("tenant_id is required either in the resource or provider config")
-	}
 	if len(clusterConfig.URI) == 0 {
 		return nil, fmt.Errorf("uri is required either in the resource or provider config")
 	}
 
-	auth := kusto.Authorization{Config: auth.NewClientCredentialsConfig(clusterConfig.ClientID, clusterConfig.ClientSecret, clusterConfig.TenantID)}
-	client, err := kusto.New(clusterConfig.URI, auth)
+	authorization, err := buildADXAuthorization(clusterConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newKustoClient(clusterConfig.URI, authorization)
 	if err != nil {
 		return nil, fmt.Errorf("error creating adx client from config: %+v", err)
 	}
@@ -187,6 +182,16 @@ func getCachedADXClient(meta interface{}, clusterConfig *ClusterConfig) *kusto.C
 	return meta.(*Meta).KustoClientsMap[configHash]
 }
 
+// expandStringSet returns the sorted values of a set of strings
+func expandStringSet(set *schema.Set) []string {
+	result := make([]string, 0, set.Len())
+	for _, v := range set.List() {
+		result = append(result, v.(string))
+	}
+	sort.Strings(result)
+	return result
+}
+
 func buildADXResourceId(endpoint string, params ...string) string {
 	endpoint = strings.Replace(endpoint, "https://", "", 1)
 	endpoint = strings.Replace(endpoint, "http://", "", 1)
@@ -210,7 +215,7 @@ func parseADXResourceID(input string, expectedParts int, uriIndex int, dbNameInd
 func toADXTimespanLiteral(ctx context.Context, meta interface{}, clusterConfig *ClusterConfig, databaseName string, input string, expectedUnit string) (string, error) {
 	// Expected unit can be d,h,m,s
 	if input != "" && expectedUnit != "" {
-		query := fmt.Sprintf("print Result=tostring(toint(totimespan('%s')/1%s))", input, expectedUnit)
+		query := fmt.Sprintf("print Result=tostring(toint(totimespan(%s)/1%s))", kql.StringLiteral(input), expectedUnit)
 		resultSet, err := queryADXAndParse[adxSimpleQueryResult](ctx, meta, clusterConfig, databaseName, query)
 		if err != nil {
 			return input, fmt.Errorf("error converting timespan literal: %+v", err)
@@ -229,27 +234,32 @@ func hashObjects(objs ...interface{}) []byte {
 }
 
 func isTableExists(ctx context.Context, meta interface{}, clusterConfig *ClusterConfig, databaseName, tableName string) (bool, error) {
-	showStatement := fmt.Sprintf(".show tables (%s) details", tableName)
+	showStatement := kql.New(".show", "tables").IdentifierList(tableName).Keyword("details").String()
 	return hasStatementResults(ctx, meta, clusterConfig, databaseName, showStatement, "checking if table exists")
 }
 
 func isMaterializedViewExists(ctx context.Context, meta interface{}, clusterConfig *ClusterConfig, databaseName, viewName string) (bool, error) {
-	showStatement := fmt.Sprintf(".show materialized-views (%s) details", viewName)
+	showStatement := kql.New(".show", "materialized-views").IdentifierList(viewName).Keyword("details").String()
 	return hasStatementResults(ctx, meta, clusterConfig, databaseName, showStatement, "checking if materialized view exists")
 }
 
 func isFunctionExists(ctx context.Context, meta interface{}, clusterConfig *ClusterConfig, databaseName, functionName string) (bool, error) {
-	showStatement := fmt.Sprintf(".show functions | where Name == '%s'", functionName)
+	showStatement := kql.New(".show", "functions", "|", "where", "Name", "==").StringLiteral(functionName).String()
 	return hasStatementResults(ctx, meta, clusterConfig, databaseName, showStatement, "checking if function exists")
 }
 
+func isExternalTableExists(ctx context.Context, meta interface{}, clusterConfig *ClusterConfig, databaseName, tableName string) (bool, error) {
+	showStatement := kql.New(".show", "external", "tables", "|", "where", "TableName", "==").StringLiteral(tableName).String()
+	return hasStatementResults(ctx, meta, clusterConfig, databaseName, showStatement, "checking if external table exists")
+}
+
 func isColumnExists(ctx context.Context, meta interface{}, clusterConfig *ClusterConfig, databaseName, columnName string) (bool, error) {
-	showStatement := fmt.Sprintf(".show column %s policy encoding", columnName)
+	showStatement := kql.New(".show", "column").QualifiedIdentifier(splitColumnReference(columnName)...).Keyword("policy", "encoding").String()
 	return hasStatementResults(ctx, meta, clusterConfig, databaseName, showStatement, "checking if column exists")
 }
 
 func isDatabaseExists(ctx context.Context, meta interface{}, clusterConfig *ClusterConfig, databaseName string) (bool, error) {
-	showStatement := fmt.Sprintf(".show database %s", escapeEntityNameIfRequired(databaseName))
+	showStatement := kql.New(".show", "database").Identifier(databaseName).String()
 	return hasStatementResults(ctx, meta, clusterConfig, databaseName, showStatement, "checking if database exists")
 }
 
@@ -264,6 +274,8 @@ func isEntityExists(ctx context.Context, meta interface{}, clusterConfig *Cluste
 		return isColumnExists(ctx, meta, clusterConfig, databaseName, entityName)
 	} else if entityType == "database" {
 		return isDatabaseExists(ctx, meta, clusterConfig, databaseName)
+	} else if entityType == "external table" {
+		return isExternalTableExists(ctx, meta, clusterConfig, databaseName, entityName)
 	}
 	return false, fmt.Errorf("checking for existance of entity type (%s) is not yet supported", entityType)
 }
@@ -305,7 +317,7 @@ func pollAsyncOperation(ctx context.Context, d *schema.ResourceData, meta interf
 
 func refreshStateAsyncOperation(ctx context.Context, meta interface{}, clusterConfig *ClusterConfig, databaseName string, operationId string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
-		query := fmt.Sprintf(".show operations %s", operationId)
+		query := kql.New(".show", "operations").Literal(operationId).String()
 		resultSet, err := queryADXMgmtAndParse[adxAsyncOperationsDetails](ctx, meta, clusterConfig, databaseName, query)
 		if err != nil {
 			return nil, "", fmt.Errorf("error checking status of operation %s: %+v", operationId, err)
@@ -315,29 +327,41 @@ func refreshStateAsyncOperation(ctx context.Context, meta interface{}, clusterCo
 }
 
 func isEntityNameEscaped(name string) bool {
-	return strings.HasPrefix(name, "['") && strings.HasSuffix(name, "']")
+	return kql.IsQuotedIdentifier(name)
 }
 
 func escapeEntityNameIfRequired(name string) string {
-	escapedName := name
-	if strings.Contains(name, "-") && !strings.HasPrefix(name, "[") {
-		escapedName = escapeEntityName(name)
-	}
-	return escapedName
+	return kql.Identifier(name)
 }
 
 func escapeEntityName(name string) string {
-	return fmt.Sprintf("['%s']", name)
+	return kql.QuoteIdentifier(name)
+}
+
+// splitColumnReference splits a Table.Column reference, as used by column policy IDs, into its parts. A dot
+// inside a quoted table name such as ['my.table'].Column does not split the reference.
+func splitColumnReference(name string) []string {
+	var quote byte
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '.':
+			return []string{name[:i], name[i+1:]}
+		}
+	}
+	return []string{name}
 }
 
-var escapedEntityNamePattern = regexp.MustCompile(`^\['(.+)'\]$`)
-
 func unescapeEntityName(name string) string {
-	matches := escapedEntityNamePattern.FindStringSubmatch(name)
-	if len(matches) == 2 {
-		return matches[1]
-	}
-	return name
+	return kql.UnquoteIdentifier(name)
 }
 
 var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
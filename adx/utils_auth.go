@@ -0,0 +1,284 @@
+package adx
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-kusto-go/kusto"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/Azure/go-autorest/autorest/azure/cli"
+)
+
+const oidcClientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+type oidcTokenResponse struct {
+	AccessToken string      `json:"access_token"`
+	ExpiresIn   json.Number `json:"expires_in"`
+	TokenType   string      `json:"token_type"`
+}
+
+const (
+	authMethodClientSecret      = "client_secret"
+	authMethodClientCertificate = "client_certificate"
+	authMethodMSI               = "msi"
+	authMethodOIDC              = "oidc"
+	authMethodAzureCLI          = "azure_cli"
+)
+
+// Application id of the Azure CLI, which tokens from the CLI cache are issued to
+const azureCLIClientID = "04b07795-8ddb-461a-bbee-02f9e1bf7b46"
+
+// getAzureCLIToken is swapped out in tests to avoid invoking the Azure CLI
+var getAzureCLIToken = cli.GetTokenFromCLIWithParams
+
+// getClusterAuthMethod selects the authentication method for a cluster config.
+// Managed identity, Azure CLI and workload identity take precedence, followed by a
+// client certificate and finally a client id/secret pair.
+func getClusterAuthMethod(clusterConfig *ClusterConfig) string {
+	switch {
+	case clusterConfig.UseMSI:
+		return authMethodMSI
+	case clusterConfig.UseCLI:
+		return authMethodAzureCLI
+	case clusterConfig.UseOIDC:
+		return authMethodOIDC
+	case hasClusterCertificate(clusterConfig):
+		return authMethodClientCertificate
+	default:
+		return authMethodClientSecret
+	}
+}
+
+func validateClusterAuthConfig(clusterConfig *ClusterConfig, authMethod string) error {
+	// Managed identity and the Azure CLI resolve the principal themselves
+	if authMethod == authMethodMSI || authMethod == authMethodAzureCLI {
+		return nil
+	}
+
+	if len(clusterConfig.ClientID) == 0 {
+		return fmt.Errorf("client_id is required either in the resource or provider config")
+	}
+	if len(clusterConfig.TenantID) == 0 {
+		return fmt.Errorf("tenant_id is required either in the resource or provider config")
+	}
+
+	switch authMethod {
+	case authMethodOIDC:
+		if len(clusterConfig.OIDCToken) == 0 && len(clusterConfig.OIDCTokenFilePath) == 0 {
+			return fmt.Errorf("oidc_token or oidc_token_file_path is required either in the resource or provider config when use_oidc is set")
+		}
+	case authMethodClientCertificate:
+		if len(clusterConfig.ClientCertificatePath) > 0 && len(clusterConfig.ClientCertificate) > 0 {
+			return fmt.Errorf("only one of client_certificate or client_certificate_path can be specified")
+		}
+	case authMethodClientSecret:
+		if len(clusterConfig.ClientSecret) == 0 {
+			return fmt.Errorf("one of client_secret, client_certificate or client_certificate_path is required either in the resource or provider config (or set use_msi, use_oidc or use_cli to authenticate without a secret)")
+		}
+	}
+	return nil
+}
+
+func buildADXAuthorization(clusterConfig *ClusterConfig) (kusto.Authorization, error) {
+	authMethod := getClusterAuthMethod(clusterConfig)
+	if err := validateClusterAuthConfig(clusterConfig, authMethod); err != nil {
+		return kusto.Authorization{}, err
+	}
+
+	var spt *adal.ServicePrincipalToken
+	var err error
+	switch authMethod {
+	case authMethodMSI:
+		msiConfig := auth.NewMSIConfig()
+		// An empty client id selects the system-assigned identity
+		msiConfig.ClientID = clusterConfig.MSIClientID
+		return kusto.Authorization{Config: msiConfig}, nil
+	case authMethodClientSecret:
+		return kusto.Authorization{Config: auth.NewClientCredentialsConfig(clusterConfig.ClientID, clusterConfig.ClientSecret, clusterConfig.TenantID)}, nil
+	case authMethodAzureCLI:
+		spt, err = buildAzureCLIServicePrincipalToken(clusterConfig)
+	case authMethodOIDC:
+		spt, err = buildOIDCServicePrincipalToken(clusterConfig)
+	case authMethodClientCertificate:
+		spt, err = buildCertificateServicePrincipalToken(clusterConfig)
+	}
+	if err != nil {
+		return kusto.Authorization{}, err
+	}
+	return kusto.Authorization{Authorizer: autorest.NewBearerAuthorizer(spt)}, nil
+}
+
+// buildAzureCLIServicePrincipalToken obtains tokens for the signed in Azure CLI user,
+// asking the CLI for a new token whenever the current one is about to expire
+func buildAzureCLIServicePrincipalToken(clusterConfig *ClusterConfig) (*adal.ServicePrincipalToken, error) {
+	tenantID := clusterConfig.TenantID
+	if len(tenantID) == 0 {
+		tenantID = "organizations"
+	}
+	oauthConfig, err := adal.NewOAuthConfig(getActiveDirectoryEndpoint(), tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("error building oauth config for azure cli authentication: %+v", err)
+	}
+
+	spt, err := adal.NewServicePrincipalTokenWithSecret(*oauthConfig, azureCLIClientID, clusterConfig.URI, &adal.ServicePrincipalNoSecret{})
+	if err != nil {
+		return nil, fmt.Errorf("error building service principal token for azure cli authentication: %+v", err)
+	}
+	spt.SetCustomRefreshFunc(func(ctx context.Context, resource string) (*adal.Token, error) {
+		token, err := getAzureCLIToken(cli.GetAccessTokenParams{Resource: resource, Tenant: clusterConfig.TenantID})
+		if err != nil {
+			return nil, fmt.Errorf("error obtaining access token from azure cli, make sure `az login` has been run: %+v", err)
+		}
+		adalToken, err := token.ToADALToken()
+		if err != nil {
+			return nil, fmt.Errorf("error parsing access token from azure cli: %+v", err)
+		}
+		return &adalToken, nil
+	})
+
+	return spt, nil
+}
+
+// buildOIDCServicePrincipalToken exchanges a federated OIDC token for a Kusto access token
+// using the client assertion flow. The token is re-read on every refresh because
+// projected token files are rotated by the platform.
+func buildOIDCServicePrincipalToken(clusterConfig *ClusterConfig) (*adal.ServicePrincipalToken, error) {
+	activeDirectoryEndpoint := getActiveDirectoryEndpoint()
+	oauthConfig, err := adal.NewOAuthConfig(activeDirectoryEndpoint, clusterConfig.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("error building oauth config for oidc authentication: %+v", err)
+	}
+
+	tokenURL, err := url.JoinPath(activeDirectoryEndpoint, clusterConfig.TenantID, "oauth2", "v2.0", "token")
+	if err != nil {
+		return nil, fmt.Errorf("error building oidc token url: %+v", err)
+	}
+
+	spt, err := adal.NewServicePrincipalTokenWithSecret(*oauthConfig, clusterConfig.ClientID, clusterConfig.URI, &adal.ServicePrincipalNoSecret{})
+	if err != nil {
+		return nil, fmt.Errorf("error building service principal token for oidc authentication: %+v", err)
+	}
+	spt.SetCustomRefreshFunc(func(ctx context.Context, resource string) (*adal.Token, error) {
+		return refreshOIDCToken(ctx, clusterConfig, tokenURL, resource)
+	})
+
+	return spt, nil
+}
+
+// buildCertificateServicePrincipalToken authenticates a service principal with a PFX certificate,
+// read either from disk or from its base64 encoded content
+func buildCertificateServicePrincipalToken(clusterConfig *ClusterConfig) (*adal.ServicePrincipalToken, error) {
+	var certData []byte
+	var err error
+	if len(clusterConfig.ClientCertificatePath) > 0 {
+		certData, err = os.ReadFile(clusterConfig.ClientCertificatePath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading client certificate file (%s): %+v", clusterConfig.ClientCertificatePath, err)
+		}
+	} else {
+		certData, err = base64.StdEncoding.DecodeString(clusterConfig.ClientCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding client_certificate, expected base64 encoded PFX content: %+v", err)
+		}
+	}
+
+	certificate, privateKey, err := adal.DecodePfxCertificateData(certData, clusterConfig.ClientCertificatePassword)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding client certificate, check client_certificate_password: %+v", err)
+	}
+
+	oauthConfig, err := adal.NewOAuthConfig(getActiveDirectoryEndpoint(), clusterConfig.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("error building oauth config for certificate authentication: %+v", err)
+	}
+
+	spt, err := adal.NewServicePrincipalTokenFromCertificate(*oauthConfig, clusterConfig.ClientID, certificate, privateKey, clusterConfig.URI)
+	if err != nil {
+		return nil, fmt.Errorf("error building service principal token for certificate authentication: %+v", err)
+	}
+	return spt, nil
+}
+
+func refreshOIDCToken(ctx context.Context, clusterConfig *ClusterConfig, tokenURL string, resource string) (*adal.Token, error) {
+	assertion, err := readOIDCToken(clusterConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"client_id":             {clusterConfig.ClientID},
+		"grant_type":            {"client_credentials"},
+		"scope":                 {strings.TrimSuffix(resource, "/") + "/.default"},
+		"client_assertion_type": {oidcClientAssertionType},
+		"client_assertion":      {assertion},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("error building oidc token request: %+v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting access token with oidc assertion: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading oidc token response: %+v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error requesting access token with oidc assertion: status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var tokenResp oidcTokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return nil, fmt.Errorf("error parsing oidc token response: %+v", err)
+	}
+	expiresIn, err := tokenResp.ExpiresIn.Int64()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing oidc token expiry %q: %+v", tokenResp.ExpiresIn, err)
+	}
+
+	return &adal.Token{
+		AccessToken: tokenResp.AccessToken,
+		ExpiresIn:   tokenResp.ExpiresIn,
+		ExpiresOn:   json.Number(strconv.FormatInt(time.Now().Add(time.Duration(expiresIn)*time.Second).Unix(), 10)),
+		Resource:    resource,
+		Type:        tokenResp.TokenType,
+	}, nil
+}
+
+func readOIDCToken(clusterConfig *ClusterConfig) (string, error) {
+	if len(clusterConfig.OIDCToken) > 0 {
+		return clusterConfig.OIDCToken, nil
+	}
+	token, err := os.ReadFile(clusterConfig.OIDCTokenFilePath)
+	if err != nil {
+		return "", fmt.Errorf("error reading oidc token file (%s): %+v", clusterConfig.OIDCTokenFilePath, err)
+	}
+	return strings.TrimSpace(string(token)), nil
+}
+
+// getActiveDirectoryEndpoint honors the authority host injected by workload identity
+// webhooks, defaulting to the public cloud
+func getActiveDirectoryEndpoint() string {
+	if v := os.Getenv("AZURE_AUTHORITY_HOST"); v != "" {
+		return v
+	}
+	return azure.PublicCloud.ActiveDirectoryEndpoint
+}
@@ -0,0 +1,167 @@
+package adx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/Azure/go-autorest/autorest/azure/cli"
+	"github.com/stretchr/testify/assert"
+)
+
+// Self-signed certificate (CN=terraform-provider-adx-test) exported as a legacy PFX with password "test"
+const testClientCertificate = "" +
+	"MIIGIQIBAzCCBecGCSqGSIb3DQEHAaCCBdgEggXUMIIF0DCCAs8GCSqGSIb3DQEHBqCCAsAwggK8AgEAMIICtQYJKoZIhvcNAQcB" +
+	"MBwGCiqGSIb3DQEMAQYwDgQIRoCQXdNQWs4CAggAgIICiEpkZtzBgOAkbcSkmPdTznqNU6rsF51GfhBI/+z1T9jb0+QlB2J3Mj0v" +
+	"Yb9j/Ee/JI+gTNtUkKB9/oKPExBMQcn/ZA0b+PBsD+uV7XIu+lGiy9Y6hesGRhTqTDueUfdsL1lLEiMtuw9tuQ5YaQ7FFOXFJ9BE" +
+	"BmGtYi1DwVSg4Q+lwjQs/PT/5bJWAW+XwK6CqmmYJPJt5sAbxuwTUmvkYy6ljoRlwlOsBaLRIiAbijqDCfpURU2dGJgHHDh2JTa8" +
+	"4+4pOIM9ieDYbXZ+p22OfFglNI5n7FL1x0HyKWHyJciBn73BOs/MvcXe7UGSeajYvnkxHw7MwMHVsFKqLmW+DTpb02mrIusiFTpG" +
+	"daEU3ooCCCXU4pUHCcCbeATk6qEe2PxQk7iB5UrYUsFTDa45b99GzRIafE4MIcjPdyv1ZrOimfOZi6Af/iJ4r9Fipe8O1k+F8v1E" +
+	"su1ZXJola5fTUHpCtuX7FIksWrCHprFJrffnl4AS11OqtIKg9IqcQS54u08rWrxFRg2YZXuglcgaSA/tmZolYPLqq7SUeJFjs/qo" +
+	"kG79DFUkeGRzcp82CgrQRE5SlNlSauY7eDzeTzEKdzvuBcRA9n1WQfWHjfEU9C4tC3BECNLn5HdJZLRxa4dch1hdRfPwBik0Qv46" +
+	"ZQ1CI6HKCssGA6htko2N5n8+5MlrApje57weTjqch7NOTe4yKE6Mm8qYzb5iUKQefbEE6IxoGW2i2wlZmdP3f+2UcIKNdU0LPRHi" +
+	"NdJIxL6LPOb/Hic+waUsRO1s97iNFHbX3+ZWDatk1iuYJYBnYcsHsXSrHybr6+3j66DBuNNxFnVG9q6anNKf/uT4r08CaaqkGprY" +
+	"s0ER3TtggTCCAvkGCSqGSIb3DQEHAaCCAuoEggLmMIIC4jCCAt4GCyqGSIb3DQEMCgECoIICpjCCAqIwHAYKKoZIhvcNAQwBAzAO" +
+	"BAjKx8vYLf/gvgICCAAEggKAbtfR9M9PnbCEd/u1GhrsxELF4IXTCO8J9EeyoxynvysGdgHdxnWwswaNgEeO5TjQWjrVbhu3F399" +
+	"LK9vQi1k5Z5xDLaImpwoOnfo+0lbaAX3oSPgqpJCok4GSKEgLWeteQsHB3IZvQ5vBO4tsGA+WpU94sQ1psBqRMHA4/hguki2dCv2" +
+	"EGg+lnwmcmWipx6v0rkbhlebnZir9ziXSCrKc6Do6pKxk5sK6g+6LiA5JAm29QY0R7zYdxf0bae1xfxxxYnnPcR0sykVDYOY3AH9" +
+	"PcLnCl9rV3Gdi3ocInPSSkguCc87AaIkbPTKB0nwPHJ343YT9C4Pa642B1uoe/NcIF6d7vB2NDyx3Q7vwkzmk72wDLN+lEhpOuqe" +
+	"o9GM8ygIVgWT/LFFEAjf9DSAWSwvom/SACYZv2lg+NMTL0NCAbylGKF/epm78gjjMk6YdJ6hx5u/B3P4GIiCYirVxiuNw9OYmGHh" +
+	"LL2PUcipH08OPkgJkCmJVdQwMT1I7Ff17G2qOW2MBGA3VpxfCsenqC7AzfnGi54V3YYqSlAz8sO2/oZU+SY+LrtODdGZLTrwg3kT" +
+	"AMsBuOTG70OmrHm9UsbudIPWwMd7gLM4h8ksdROak6FJplHdkqrK/x3ut7+UiEWOSgfV5ev8yfX5beV+Ri94db07mV9L/gMNOpM3" +
+	"WYHuhULPHgWYWCqscY6TQqno6znTROaD50up2DowgxBzvcXBF0vcFD6ZLZXqonuHoa77q3X5l4hlhrw3ULdtkkLgIPAmxrL5GNhF" +
+	"eGBn/kmWTgQTqHdWKOHdmPTCmix63g16p7ilB/tMegFquLZ+nzisP5Bm7DqJ7goaY6lNc2Nhn7kw+DElMCMGCSqGSIb3DQEJFTEW" +
+	"BBTszWaRth0iCJdo2ObMyI1ZsXTXlzAxMCEwCQYFKw4DAhoFAAQU65j3x2CF4naGWmcXqIH2p4u/xeUECLvTYw2eC3P4AgIIAA=="
+
+func TestUtils_buildADXAuthorization_msi(t *testing.T) {
+	authorization, err := buildADXAuthorization(&ClusterConfig{URI: "https://test.kusto.windows.net", UseMSI: true, MSIClientID: "client"})
+	assert.NoError(t, err, "managed identity should not require a client id or secret")
+
+	msiConfig, ok := authorization.Config.(auth.MSIConfig)
+	assert.True(t, ok, "managed identity should use an msi authorizer config")
+	assert.Equal(t, "client", msiConfig.ClientID, "user-assigned identity client id should be passed through")
+	assert.Equal(t, authMethodMSI, getClusterAuthMethod(&ClusterConfig{UseMSI: true, ClientSecret: "secret"}), "msi should take precedence over a secret")
+}
+
+func TestUtils_applyClusterConfigDefaults_msi(t *testing.T) {
+	defaultConfig := &ClusterConfig{URI: "https://default.kusto.windows.net", UseMSI: true}
+
+	clusterConfig := &ClusterConfig{}
+	applyClusterConfigDefaults(clusterConfig, defaultConfig)
+	assert.True(t, clusterConfig.UseMSI, "msi should be inherited from the provider")
+
+	clusterConfig = &ClusterConfig{ClientID: "client", ClientSecret: "secret", TenantID: "tenant"}
+	applyClusterConfigDefaults(clusterConfig, defaultConfig)
+	assert.False(t, clusterConfig.UseMSI, "a cluster block with its own secret should not inherit msi")
+	assert.NotEqual(t, hashClusterConfig(clusterConfig), hashClusterConfig(&ClusterConfig{URI: clusterConfig.URI, UseMSI: true}), "msi and secret clients should not share a cache entry")
+}
+
+func TestUtils_buildOIDCServicePrincipalToken(t *testing.T) {
+	var form url.Values
+	tokenEndpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/tenant/oauth2/v2.0/token", r.URL.Path, "token should be requested from the tenant v2 endpoint")
+		r.ParseForm()
+		form = r.PostForm
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"kusto-token","expires_in":3599,"token_type":"Bearer"}`))
+	}))
+	defer tokenEndpoint.Close()
+	t.Setenv("AZURE_AUTHORITY_HOST", tokenEndpoint.URL)
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	os.WriteFile(tokenFile, []byte("federated-token\n"), 0600)
+
+	spt, err := buildOIDCServicePrincipalToken(&ClusterConfig{
+		URI:               "https://test.kusto.windows.net",
+		ClientID:          "client",
+		TenantID:          "tenant",
+		UseOIDC:           true,
+		OIDCTokenFilePath: tokenFile,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, spt.EnsureFresh(), "token should be exchanged with the stand-in endpoint")
+
+	assert.Equal(t, "kusto-token", spt.OAuthToken())
+	assert.Equal(t, "federated-token", form.Get("client_assertion"), "federated token should be sent as the client assertion")
+	assert.Equal(t, oidcClientAssertionType, form.Get("client_assertion_type"))
+	assert.Equal(t, "client", form.Get("client_id"))
+	assert.Equal(t, "https://test.kusto.windows.net/.default", form.Get("scope"))
+}
+
+func TestUtils_buildOIDCServicePrincipalToken_tokenRequired(t *testing.T) {
+	_, err := buildADXAuthorization(&ClusterConfig{URI: "https://test.kusto.windows.net", ClientID: "client", TenantID: "tenant", UseOIDC: true})
+	assert.ErrorContains(t, err, "oidc_token or oidc_token_file_path is required")
+}
+
+func TestUtils_hashClusterConfig_oidc(t *testing.T) {
+	secretConfig := &ClusterConfig{URI: "https://test.kusto.windows.net", ClientID: "client", TenantID: "tenant", ClientSecret: "secret"}
+	oidcConfig := &ClusterConfig{URI: "https://test.kusto.windows.net", ClientID: "client", TenantID: "tenant", UseOIDC: true, OIDCToken: "token"}
+	assert.NotEqual(t, hashClusterConfig(secretConfig), hashClusterConfig(oidcConfig), "oidc and secret clients should not share a cache entry")
+}
+
+func TestUtils_buildCertificateServicePrincipalToken(t *testing.T) {
+	var form url.Values
+	tokenEndpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/tenant/oauth2/token", r.URL.Path, "token should be requested from the tenant endpoint")
+		r.ParseForm()
+		form = r.PostForm
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"kusto-token","expires_in":"3599","expires_on":"4102444800","token_type":"Bearer"}`))
+	}))
+	defer tokenEndpoint.Close()
+	t.Setenv("AZURE_AUTHORITY_HOST", tokenEndpoint.URL)
+
+	spt, err := buildCertificateServicePrincipalToken(&ClusterConfig{
+		URI:                       "https://test.kusto.windows.net",
+		ClientID:                  "client",
+		TenantID:                  "tenant",
+		ClientCertificate:         testClientCertificate,
+		ClientCertificatePassword: "test",
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, spt.EnsureFresh(), "token should be requested from the stand-in endpoint")
+
+	assert.Equal(t, "kusto-token", spt.OAuthToken())
+	assert.Equal(t, oidcClientAssertionType, form.Get("client_assertion_type"), "certificate should be presented as a signed client assertion")
+	assert.NotEmpty(t, form.Get("client_assertion"))
+}
+
+func TestUtils_buildCertificateServicePrincipalToken_wrongPassword(t *testing.T) {
+	_, err := buildADXAuthorization(&ClusterConfig{
+		URI:                       "https://test.kusto.windows.net",
+		ClientID:                  "client",
+		TenantID:                  "tenant",
+		ClientCertificate:         testClientCertificate,
+		ClientCertificatePassword: "wrong",
+	})
+	assert.ErrorContains(t, err, "check client_certificate_password")
+}
+
+func TestUtils_buildADXAuthorization_credentialRequired(t *testing.T) {
+	_, err := buildADXAuthorization(&ClusterConfig{URI: "https://test.kusto.windows.net", ClientID: "client", TenantID: "tenant"})
+	assert.ErrorContains(t, err, "one of client_secret, client_certificate or client_certificate_path is required")
+}
+
+func TestUtils_buildAzureCLIServicePrincipalToken(t *testing.T) {
+	var params cli.GetAccessTokenParams
+	getAzureCLIToken = func(p cli.GetAccessTokenParams) (*cli.Token, error) {
+		params = p
+		return &cli.Token{AccessToken: "kusto-token", ExpiresOn: "2099-01-01 00:00:00.000000", TokenType: "Bearer"}, nil
+	}
+	defer func() { getAzureCLIToken = cli.GetTokenFromCLIWithParams }()
+
+	clusterConfig := &ClusterConfig{URI: "https://test.kusto.windows.net", UseCLI: true}
+	_, err := buildADXAuthorization(clusterConfig)
+	assert.NoError(t, err, "the azure cli should not require a client id, secret or tenant")
+
+	spt, err := buildAzureCLIServicePrincipalToken(clusterConfig)
+	assert.NoError(t, err)
+	assert.NoError(t, spt.EnsureFresh(), "token should be obtained from the azure cli")
+
+	assert.Equal(t, "kusto-token", spt.OAuthToken())
+	assert.Equal(t, "https://test.kusto.windows.net", params.Resource, "token should be requested for the cluster")
+}
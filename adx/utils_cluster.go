@@ -9,10 +9,20 @@ import (
 )
 
 type ClusterConfig struct {
-	ClientID     string
-	ClientSecret string
-	TenantID     string
-	URI          string
+	ClientID          string
+	ClientSecret      string
+	TenantID          string
+	URI               string
+	UseMSI            bool
+	MSIClientID       string
+	UseOIDC           bool
+	OIDCToken         string
+	OIDCTokenFilePath string
+	UseCLI            bool
+
+	ClientCertificatePath     string
+	ClientCertificate         string
+	ClientCertificatePassword string
 }
 
 func getClusterConfigInputSchema() *schema.Schema {
@@ -44,6 +54,54 @@ func getClusterConfigInputSchema() *schema.Schema {
 					Optional: true,
 					Computed: true,
 				},
+				"use_msi": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Computed: true,
+				},
+				"msi_client_id": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Computed: true,
+				},
+				"use_oidc": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Computed: true,
+				},
+				"oidc_token": {
+					Type:      schema.TypeString,
+					Sensitive: true,
+					Optional:  true,
+					Computed:  true,
+				},
+				"oidc_token_file_path": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Computed: true,
+				},
+				"use_cli": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Computed: true,
+				},
+				"client_certificate_path": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Computed: true,
+				},
+				"client_certificate": {
+					Type:      schema.TypeString,
+					Sensitive: true,
+					Optional:  true,
+					Computed:  true,
+				},
+				"client_certificate_password": {
+					Type:      schema.TypeString,
+					Sensitive: true,
+					Optional:  true,
+					Computed:  true,
+				},
 			},
 		},
 	}
@@ -97,6 +155,30 @@ func clusterConfigCustomDiff(ctx context.Context, diff *schema.ResourceDiff, met
 			newClusterMap["tenant_id"] = defaultConfig.TenantID
 			log.Printf("[DEBUG] Defaulting cluster[0].tenant_id diff to provider config: %s", defaultConfig.TenantID)
 		}
+		if oldClusterConfig.MSIClientID != "" && newClusterConfig.MSIClientID == "" {
+			newClusterMap["msi_client_id"] = defaultConfig.MSIClientID
+			log.Printf("[DEBUG] Defaulting cluster[0].msi_client_id diff to provider config: %s", defaultConfig.MSIClientID)
+		}
+		if oldClusterConfig.OIDCToken != "" && newClusterConfig.OIDCToken == "" {
+			newClusterMap["oidc_token"] = defaultConfig.OIDCToken
+			log.Printf("[DEBUG] Defaulting cluster[0].oidc_token diff to provider config")
+		}
+		if oldClusterConfig.OIDCTokenFilePath != "" && newClusterConfig.OIDCTokenFilePath == "" {
+			newClusterMap["oidc_token_file_path"] = defaultConfig.OIDCTokenFilePath
+			log.Printf("[DEBUG] Defaulting cluster[0].oidc_token_file_path diff to provider config: %s", defaultConfig.OIDCTokenFilePath)
+		}
+		if oldClusterConfig.ClientCertificatePath != "" && newClusterConfig.ClientCertificatePath == "" {
+			newClusterMap["client_certificate_path"] = defaultConfig.ClientCertificatePath
+			log.Printf("[DEBUG] Defaulting cluster[0].client_certificate_path diff to provider config: %s", defaultConfig.ClientCertificatePath)
+		}
+		if oldClusterConfig.ClientCertificate != "" && newClusterConfig.ClientCertificate == "" {
+			newClusterMap["client_certificate"] = defaultConfig.ClientCertificate
+			log.Printf("[DEBUG] Defaulting cluster[0].client_certificate diff to provider config")
+		}
+		if oldClusterConfig.ClientCertificatePassword != "" && newClusterConfig.ClientCertificatePassword == "" {
+			newClusterMap["client_certificate_password"] = defaultConfig.ClientCertificatePassword
+			log.Printf("[DEBUG] Defaulting cluster[0].client_certificate_password diff to provider config")
+		}
 		diff.SetNew("cluster", newCluster)
 	}
 
@@ -104,6 +186,22 @@ func clusterConfigCustomDiff(ctx context.Context, diff *schema.ResourceDiff, met
 }
 
 func applyClusterConfigDefaults(clusterConfig *ClusterConfig, defaultConfig *ClusterConfig) {
+	// Only inherit the provider authentication method when the cluster block does not bring
+	// its own credentials, so that differently authenticated clusters can coexist
+	if !hasClusterCredentials(clusterConfig) {
+		clusterConfig.UseMSI = defaultConfig.UseMSI
+		clusterConfig.UseOIDC = defaultConfig.UseOIDC
+		clusterConfig.UseCLI = defaultConfig.UseCLI
+		// The certificate and its password are inherited together, a certificate in the
+		// cluster block must not be decoded with the provider password
+		clusterConfig.ClientCertificatePath = defaultConfig.ClientCertificatePath
+		clusterConfig.ClientCertificate = defaultConfig.ClientCertificate
+		clusterConfig.ClientCertificatePassword = defaultConfig.ClientCertificatePassword
+	}
+	if len(clusterConfig.MSIClientID) == 0 {
+		log.Printf("[DEBUG] Using default MSIClientID from provider for cluster config")
+		clusterConfig.MSIClientID = defaultConfig.MSIClientID
+	}
 	if len(clusterConfig.ClientID) == 0 {
 		log.Printf("[DEBUG] Using default ClientID from provider for cluster config")
 		clusterConfig.ClientID = defaultConfig.ClientID
@@ -116,12 +214,29 @@ func applyClusterConfigDefaults(clusterConfig *ClusterConfig, defaultConfig *Clu
 		log.Printf("[DEBUG] Using default TenantID from provider for cluster config")
 		clusterConfig.TenantID = defaultConfig.TenantID
 	}
+	if len(clusterConfig.OIDCToken) == 0 {
+		log.Printf("[DEBUG] Using default OIDCToken from provider for cluster config")
+		clusterConfig.OIDCToken = defaultConfig.OIDCToken
+	}
+	if len(clusterConfig.OIDCTokenFilePath) == 0 {
+		log.Printf("[DEBUG] Using default OIDCTokenFilePath from provider for cluster config")
+		clusterConfig.OIDCTokenFilePath = defaultConfig.OIDCTokenFilePath
+	}
 	if len(clusterConfig.URI) == 0 {
 		log.Printf("[DEBUG] Using default URI from provider for cluster config")
 		clusterConfig.URI = defaultConfig.URI
 	}
 }
 
+// hasClusterCredentials checks if a cluster config selects its own authentication method
+func hasClusterCredentials(clusterConfig *ClusterConfig) bool {
+	return len(clusterConfig.ClientSecret) > 0 || clusterConfig.UseMSI || clusterConfig.UseOIDC || clusterConfig.UseCLI || hasClusterCertificate(clusterConfig)
+}
+
+func hasClusterCertificate(clusterConfig *ClusterConfig) bool {
+	return len(clusterConfig.ClientCertificatePath) > 0 || len(clusterConfig.ClientCertificate) > 0
+}
+
 func getAndExpandClusterConfigWithDefaults(ctx context.Context, d *schema.ResourceData, meta interface{}) *ClusterConfig {
 	clusterConfig := getAndExpandClusterConfig(ctx, d)
 	defaultConfig := meta.(*Meta).DefaultClusterConfig
@@ -146,10 +261,20 @@ func expandClusterConfig(input interface{}) *ClusterConfig {
 	clusterInputMap := input.(map[string]interface{})
 
 	return &ClusterConfig{
-		ClientID:     getAttributeOrDefault(clusterInputMap, "client_id", ""),
-		ClientSecret: getAttributeOrDefault(clusterInputMap, "client_secret", ""),
-		TenantID:     getAttributeOrDefault(clusterInputMap, "tenant_id", ""),
-		URI:          getAttributeOrDefault(clusterInputMap, "uri", ""),
+		ClientID:          getAttributeOrDefault(clusterInputMap, "client_id", ""),
+		ClientSecret:      getAttributeOrDefault(clusterInputMap, "client_secret", ""),
+		TenantID:          getAttributeOrDefault(clusterInputMap, "tenant_id", ""),
+		URI:               getAttributeOrDefault(clusterInputMap, "uri", ""),
+		UseMSI:            getBoolAttributeOrDefault(clusterInputMap, "use_msi", false),
+		MSIClientID:       getAttributeOrDefault(clusterInputMap, "msi_client_id", ""),
+		UseOIDC:           getBoolAttributeOrDefault(clusterInputMap, "use_oidc", false),
+		OIDCToken:         getAttributeOrDefault(clusterInputMap, "oidc_token", ""),
+		OIDCTokenFilePath: getAttributeOrDefault(clusterInputMap, "oidc_token_file_path", ""),
+		UseCLI:            getBoolAttributeOrDefault(clusterInputMap, "use_cli", false),
+
+		ClientCertificatePath:     getAttributeOrDefault(clusterInputMap, "client_certificate_path", ""),
+		ClientCertificate:         getAttributeOrDefault(clusterInputMap, "client_certificate", ""),
+		ClientCertificatePassword: getAttributeOrDefault(clusterInputMap, "client_certificate_password", ""),
 	}
 }
 
@@ -160,6 +285,13 @@ func getAttributeOrDefault(d map[string]interface{}, name string, defaultString
 	return defaultString
 }
 
+func getBoolAttributeOrDefault(d map[string]interface{}, name string, defaultBool bool) bool {
+	if val := d[name]; val != nil {
+		return val.(bool)
+	}
+	return defaultBool
+}
+
 func flattenAndSetClusterConfig(ctx context.Context, d *schema.ResourceData, clusterConfig *ClusterConfig) {
 	d.Set("cluster", flattenClusterConfig(clusterConfig))
 }
@@ -171,10 +303,19 @@ func flattenClusterConfig(clusterConfig *ClusterConfig) []map[string]interface{}
 	cluster[0]["client_secret"] = clusterConfig.ClientSecret
 	cluster[0]["tenant_id"] = clusterConfig.TenantID
 	cluster[0]["uri"] = clusterConfig.URI
+	cluster[0]["use_msi"] = clusterConfig.UseMSI
+	cluster[0]["msi_client_id"] = clusterConfig.MSIClientID
+	cluster[0]["use_oidc"] = clusterConfig.UseOIDC
+	cluster[0]["oidc_token"] = clusterConfig.OIDCToken
+	cluster[0]["oidc_token_file_path"] = clusterConfig.OIDCTokenFilePath
+	cluster[0]["use_cli"] = clusterConfig.UseCLI
+	cluster[0]["client_certificate_path"] = clusterConfig.ClientCertificatePath
+	cluster[0]["client_certificate"] = clusterConfig.ClientCertificate
+	cluster[0]["client_certificate_password"] = clusterConfig.ClientCertificatePassword
 	return cluster
 }
 
 func hashClusterConfig(clusterConfig *ClusterConfig) string {
-	hash := hashObjects([]interface{}{clusterConfig.ClientID, clusterConfig.ClientSecret, clusterConfig.TenantID, clusterConfig.URI})
+	hash := hashObjects([]interface{}{clusterConfig.ClientID, clusterConfig.ClientSecret, clusterConfig.TenantID, clusterConfig.URI, clusterConfig.UseMSI, clusterConfig.MSIClientID, clusterConfig.UseOIDC, clusterConfig.OIDCToken, clusterConfig.OIDCTokenFilePath, clusterConfig.UseCLI, clusterConfig.ClientCertificatePath, clusterConfig.ClientCertificate, clusterConfig.ClientCertificatePassword})
 	return hex.EncodeToString(hash)
 }
@@ -0,0 +1,141 @@
+package adx
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// clusterPolicyEntryKey identifies an entry of a cluster policy holding a list of entries, such as the callout policy
+type clusterPolicyEntryKey func(entry map[string]interface{}) string
+
+func parseADXClusterPolicyID(input string) (*adxResourceId, error) {
+	return parseADXResourceID(input, 4, 0, 1, 2, 3)
+}
+
+// alterMergeClusterPolicy merges the given properties or entries into a cluster policy, leaving the rest of it untouched
+func alterMergeClusterPolicy(ctx context.Context, d *schema.ResourceData, meta interface{}, policyName string, policy interface{}) diag.Diagnostics {
+	clusterConfig := getAndExpandClusterConfigWithDefaults(ctx, d, meta)
+	databaseName := d.Get("database_name").(string)
+
+	policyJSON, err := kql.JSON(policy)
+	if err != nil {
+		return diag.Errorf("error serializing cluster policy %s: %+v", policyName, err)
+	}
+	alterStatement := kql.New(".alter-merge", "cluster", "policy", policyName).Literal(policyJSON).String()
+
+	client, err := getADXClient(meta, clusterConfig)
+	if err != nil {
+		return diag.Errorf("error creating adx client connection: %+v", err)
+	}
+
+	_, err = executeADXMgmt(ctx, meta, client, databaseName, alterStatement)
+	if err != nil {
+		return diag.Errorf("error altering cluster policy %s (Database %q): %+v", policyName, databaseName, err)
+	}
+
+	d.SetId(buildADXResourceId(clusterConfig.URI, databaseName, "cluster", policyName))
+
+	return nil
+}
+
+// readClusterPolicy returns the JSON of a cluster policy, or an empty string when the policy is not set
+func readClusterPolicy(ctx context.Context, d *schema.ResourceData, meta interface{}, policyName string) (*adxResourceId, string, diag.Diagnostics) {
+	clusterConfig := getAndExpandClusterConfigWithDefaults(ctx, d, meta)
+
+	id, err := parseADXClusterPolicyID(d.Id())
+	if err != nil {
+		return nil, "", diag.Errorf("error parsing cluster policy ID: %+v", err)
+	}
+
+	resultSet, diags := readADXEntity[TablePolicy](ctx, meta, clusterConfig, id, kql.New(".show", "cluster", "policy", policyName).String(), "cluster")
+	if diags.HasError() {
+		return id, "", diags
+	}
+
+	if len(resultSet) < 1 || resultSet[0].Policy == "null" {
+		return id, "", diags
+	}
+	return id, resultSet[0].Policy, diags
+}
+
+// readClusterPolicyEntries reads a cluster policy holding a list of entries
+func readClusterPolicyEntries(ctx context.Context, d *schema.ResourceData, meta interface{}, policyName string) (*adxResourceId, []map[string]interface{}, diag.Diagnostics) {
+	id, policy, diags := readClusterPolicy(ctx, d, meta, policyName)
+	if diags.HasError() || policy == "" {
+		return id, nil, diags
+	}
+
+	var entries []map[string]interface{}
+	if err := json.Unmarshal([]byte(policy), &entries); err != nil {
+		return id, nil, diag.Errorf("error parsing cluster policy %s: %+v", policyName, err)
+	}
+	return id, entries, diags
+}
+
+// removeClusterPolicyEntries removes the entries with the given keys from a cluster policy. Entries cannot be
+// removed with .alter-merge, so the remaining entries are set with .alter.
+func removeClusterPolicyEntries(ctx context.Context, d *schema.ResourceData, meta interface{}, policyName string, entryKey clusterPolicyEntryKey, removed map[string]bool) diag.Diagnostics {
+	if len(removed) == 0 {
+		return nil
+	}
+
+	id, entries, diags := readClusterPolicyEntries(ctx, d, meta, policyName)
+	if diags.HasError() {
+		return diags
+	}
+
+	remaining := make([]map[string]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		if !removed[entryKey(entry)] {
+			remaining = append(remaining, entry)
+		}
+	}
+	if len(remaining) == len(entries) {
+		return nil
+	}
+
+	policyJSON, err := kql.JSON(remaining)
+	if err != nil {
+		return diag.Errorf("error serializing cluster policy %s: %+v", policyName, err)
+	}
+	alterStatement := kql.New(".alter", "cluster", "policy", policyName).Literal(policyJSON).String()
+
+	clusterConfig := getAndExpandClusterConfigWithDefaults(ctx, d, meta)
+	client, err := getADXClient(meta, clusterConfig)
+	if err != nil {
+		return diag.Errorf("error creating adx client connection: %+v", err)
+	}
+
+	_, err = executeADXMgmt(ctx, meta, client, id.DatabaseName, alterStatement)
+	if err != nil {
+		return diag.Errorf("error removing entries from cluster policy %s (Database %q): %+v", policyName, id.DatabaseName, err)
+	}
+	return nil
+}
+
+// removedClusterPolicyEntries returns the keys of the entries that were managed before and are no longer configured
+func removedClusterPolicyEntries(d *schema.ResourceData, key string, entryKey clusterPolicyEntryKey) map[string]bool {
+	oldEntries, newEntries := d.GetChange(key)
+
+	configured := map[string]bool{}
+	for _, v := range newEntries.([]interface{}) {
+		if v != nil {
+			configured[entryKey(v.(map[string]interface{}))] = true
+		}
+	}
+
+	removed := map[string]bool{}
+	for _, v := range oldEntries.([]interface{}) {
+		if v == nil {
+			continue
+		}
+		if k := entryKey(v.(map[string]interface{})); !configured[k] {
+			removed[k] = true
+		}
+	}
+	return removed
+}
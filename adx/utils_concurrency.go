@@ -0,0 +1,45 @@
+package adx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// acquireADXCommandSlot blocks until a command may be sent to the cluster at endpoint, the returned
+// function releases the slot. Commands are not limited when max_concurrent_commands is not set.
+func acquireADXCommandSlot(ctx context.Context, meta interface{}, endpoint string) (func(), error) {
+	semaphore := getADXCommandSemaphore(meta, endpoint)
+	if semaphore == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case semaphore <- struct{}{}:
+		return func() { <-semaphore }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for a free command slot on cluster %q: %+v", endpoint, ctx.Err())
+	}
+}
+
+// getADXCommandSemaphore returns the semaphore of the cluster, creating it on first use. It is shared by the
+// resources of this provider configuration, each provider alias limits its own commands.
+func getADXCommandSemaphore(meta interface{}, endpoint string) chan struct{} {
+	m, ok := meta.(*Meta)
+	if !ok || m.MaxConcurrentCommands <= 0 {
+		return nil
+	}
+
+	key := strings.TrimSuffix(strings.ToLower(endpoint), "/")
+	m.CommandSemaphoresMU.Lock()
+	defer m.CommandSemaphoresMU.Unlock()
+	if m.CommandSemaphores == nil {
+		m.CommandSemaphores = make(map[string]chan struct{})
+	}
+	semaphore, ok := m.CommandSemaphores[key]
+	if !ok {
+		semaphore = make(chan struct{}, m.MaxConcurrentCommands)
+		m.CommandSemaphores[key] = semaphore
+	}
+	return semaphore
+}
@@ -0,0 +1,69 @@
+package adx
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUtils_acquireADXCommandSlot(t *testing.T) {
+	meta := &Meta{MaxConcurrentCommands: 2}
+	ctx := context.Background()
+
+	release1, err := acquireADXCommandSlot(ctx, meta, "https://cluster.kusto.windows.net")
+	assert.NoError(t, err)
+	release2, err := acquireADXCommandSlot(ctx, meta, "https://Cluster.kusto.windows.net/")
+	assert.NoError(t, err, "the second slot of the cluster should be free")
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err = acquireADXCommandSlot(timeoutCtx, meta, "https://cluster.kusto.windows.net")
+	assert.ErrorContains(t, err, "waiting for a free command slot", "the same cluster with a different spelling should share the limit")
+
+	releaseOther, err := acquireADXCommandSlot(ctx, meta, "https://other.kusto.windows.net")
+	assert.NoError(t, err, "other clusters should have their own limit")
+	releaseOther()
+
+	release1()
+	release3, err := acquireADXCommandSlot(ctx, meta, "https://cluster.kusto.windows.net")
+	assert.NoError(t, err, "a released slot should be reused")
+	release2()
+	release3()
+}
+
+func TestUtils_acquireADXCommandSlot_unlimited(t *testing.T) {
+	meta := &Meta{}
+	for i := 0; i < 100; i++ {
+		_, err := acquireADXCommandSlot(context.Background(), meta, "https://cluster.kusto.windows.net")
+		assert.NoError(t, err)
+	}
+	assert.Empty(t, meta.CommandSemaphores)
+}
+
+func TestFakeADXConcurrency_limit(t *testing.T) {
+	f := newFakeKusto(t)
+	meta := f.meta()
+	meta.MaxConcurrentCommands = 1
+
+	release, err := acquireADXCommandSlot(context.Background(), meta, f.server.URL)
+	assert.NoError(t, err)
+
+	done := make(chan error)
+	go func() {
+		_, err := queryADXMgmt(context.Background(), meta, meta.DefaultClusterConfig, "fake_db", ".show functions")
+		done <- err
+	}()
+
+	select {
+	case <-done:
+		t.Fatalf("command should wait for the slot held by the test")
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.Empty(t, f.Commands())
+
+	release()
+	assert.NoError(t, <-done)
+	assert.Len(t, f.Commands(), 1)
+}
@@ -5,8 +5,7 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/Azure/azure-kusto-go/kusto"
-	"github.com/Azure/azure-kusto-go/kusto/unsafe"
+	"github.com/favoretti/terraform-provider-adx/adx/kql"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -58,8 +57,7 @@ func createADXPolicy(ctx context.Context, d *schema.ResourceData, meta interface
 		return diag.Errorf("error creating adx client connection: %+v", err)
 	}
 
-	kStmtOpts := kusto.UnsafeStmt(unsafe.Stmt{Add: true})
-	_, err = client.Mgmt(ctx, databaseName, kusto.NewStmt("", kStmtOpts).UnsafeAdd(createStatement))
+	_, err = executeADXMgmt(ctx, meta, client, databaseName, createStatement)
 	if err != nil {
 		return diag.Errorf("error creating %s %s Policy %q (Database %q): %+v", entityType, policyName, entityName, databaseName, err)
 	}
@@ -79,15 +77,17 @@ func readADXPolicy(ctx context.Context, d *schema.ResourceData, meta interface{}
 		return nil, nil, diag.Errorf("could not read adx policy due to error parsing ID: %+v", err)
 	}
 
-	if entityExists, err := isEntityExists(ctx, meta, clusterConfig, id.DatabaseName, entityType, id.Name); err != nil || !entityExists {
-		if err != nil {
-			return id, nil, diag.Errorf("%+v", err)
+	if entityType != "cluster" {
+		if entityExists, err := isEntityExists(ctx, meta, clusterConfig, id.DatabaseName, entityType, id.Name); err != nil || !entityExists {
+			if err != nil {
+				return id, nil, diag.Errorf("%+v", err)
+			}
+			d.SetId("")
+			return id, nil, diags
 		}
-		d.SetId("")
-		return id, nil, diags
 	}
 
-	showCommand := fmt.Sprintf(".show %s %s policy %s", entityType, id.Name, policyName)
+	showCommand := policyEntity(kql.New(".show"), entityType, id.Name).Keyword("policy", policyName).String()
 
 	resultSet, diags := readADXEntity[TablePolicy](ctx, meta, clusterConfig, &id.adxResourceId, showCommand, entityType)
 	if diags.HasError() {
@@ -107,14 +107,21 @@ func deleteADXPolicy(ctx context.Context, d *schema.ResourceData, meta interface
 		return diag.Errorf("could not delete adx policy due to error parsing ID: %+v", err)
 	}
 
-	followerDatabaseClause := ""
-	if followerDatabase, ok := d.GetOk("follower_database"); ok {
-		if followerDatabase.(bool) {
-			followerDatabaseClause = fmt.Sprintf("follower database %s", escapeEntityNameIfRequired(id.DatabaseName))
-		}
+	deleteStatement := kql.New(".delete")
+	followerDatabase := false
+	if v, ok := d.GetOk("follower_database"); ok {
+		followerDatabase = v.(bool)
+	}
+	if followerDatabase {
+		deleteStatement.Keyword("follower", "database").Identifier(id.DatabaseName)
 	}
+	// the policies of a follower database itself are addressed by the follower clause alone
+	if !followerDatabase || entityType != "database" {
+		policyEntity(deleteStatement, entityType, id.Name)
+	}
+	deleteStatement.Keyword("policy", policyName)
 
-	return deleteADXEntity(ctx, d, meta, clusterConfig, id.DatabaseName, fmt.Sprintf(".delete %s %s %s policy %s", followerDatabaseClause, entityType, id.Name, policyName))
+	return deleteADXEntity(ctx, d, meta, clusterConfig, id.DatabaseName, deleteStatement.String())
 }
 
 func policyCacheValueStateRefresh(ctx context.Context, meta interface{}, clusterConfig *ClusterConfig, databaseName string, entityType string, entityName string, expectedUnit string) resource.StateRefreshFunc {
@@ -129,7 +136,8 @@ func policyCacheValueStateRefresh(ctx context.Context, meta interface{}, cluster
 
 func getPolicyHotCacheValue(ctx context.Context, meta interface{}, clusterConfig *ClusterConfig, databaseName string, entityType string, entityName string, expectedUnit string) (string, error) {
 	// Expected unit can be d,h,m,s
-	query := fmt.Sprintf(".show %s %s policy caching | project Result=tostring(toint(totimespan(todynamic(Policy).DataHotSpan.Value)/1%s))", entityType, entityName, expectedUnit)
+	query := kql.New(".show", entityType).Identifier(entityName).
+		Keyword("policy", "caching").Query(fmt.Sprintf("| project Result=tostring(toint(totimespan(todynamic(Policy).DataHotSpan.Value)/1%s))", expectedUnit)).String()
 	resultSet, err := queryADXMgmtAndParse[adxSimpleQueryResult](ctx, meta, clusterConfig, databaseName, query)
 	if err != nil {
 		return "", fmt.Errorf("error checking hot cache value for %s %s: %+v", entityType, entityName, err)
@@ -149,10 +157,28 @@ func policyRestrictedViewStateRefresh(ctx context.Context, meta interface{}, clu
 
 func getPolicyRestrictedViewValue(ctx context.Context, meta interface{}, clusterConfig *ClusterConfig, databaseName string, entityType string, entityName string) (string, error) {
 	// Expected unit can be d,h,m,s
-	query := fmt.Sprintf(".show %s %s policy restricted_view_access | project Result= parse_json(Policy).IsEnabled", entityType, entityName)
+	query := kql.New(".show", entityType).Identifier(entityName).Keyword("policy", "restricted_view_access").Query("| project Result=parse_json(Policy).IsEnabled").String()
+
 	resultSet, err := queryADXMgmtAndParse[adxSimpleQueryResult](ctx, meta, clusterConfig, databaseName, query)
 	if err != nil {
 		return "", fmt.Errorf("error checking restricted view value for %s %s: %+v", entityType, entityName, err)
 	}
 	return fmt.Sprintf("%s", resultSet[0].Result), nil
 }
+
+// policyEntity appends the entity a policy applies to, cluster policies have no entity name
+func policyEntity(statement *kql.Builder, entityType string, name string) *kql.Builder {
+	if entityType == "cluster" {
+		return statement.Keyword(entityType)
+	}
+	return statement.Keyword(entityType).QualifiedIdentifier(splitPolicyEntityName(entityType, name)...)
+}
+
+// splitPolicyEntityName splits the name of the entity a policy applies to into identifiers, column policies
+// reference their column as Table.Column
+func splitPolicyEntityName(entityType string, name string) []string {
+	if entityType == "column" {
+		return splitColumnReference(name)
+	}
+	return []string{name}
+}
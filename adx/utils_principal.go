@@ -0,0 +1,117 @@
+package adx
+
+import (
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// roleToDisplayName maps KQL command role names (plural) to the display names
+// returned by `.show <entity> <name> principals` (singular).
+func roleToDisplayName(role string) string {
+	switch role {
+	case "admins":
+		return "Admin"
+	case "ingestors":
+		return "Ingestor"
+	case "users":
+		return "User"
+	case "viewers":
+		return "Viewer"
+	case "unrestrictedviewers":
+		return "UnrestrictedViewer"
+	case "monitors":
+		return "Monitor"
+	default:
+		return role
+	}
+}
+
+// matchesRole checks if the role of a principal, e.g. "Database Viewer", is the given KQL role.
+// The display name must be a whole word so that viewers does not match "Database UnrestrictedViewer".
+func matchesRole(p TablePrincipal, role string) bool {
+	displayRole := strings.ToLower(roleToDisplayName(role))
+	actual := strings.ToLower(p.Role)
+	return actual == displayRole || strings.HasSuffix(actual, " "+displayRole)
+}
+
+// findPrincipal returns the principal holding role that matches the user-provided FQN, or nil
+func findPrincipal(principals []TablePrincipal, role string, inputFQN string) *TablePrincipal {
+	for i := range principals {
+		if matchesPrincipal(principals[i], inputFQN) && matchesRole(principals[i], role) {
+			return &principals[i]
+		}
+	}
+	return nil
+}
+
+// resolvedPrincipalFQN returns the FQN ADX resolved the user-provided FQN to, as listed in the result of
+// an .add command, falling back to the user-provided FQN when the principal is not listed
+func resolvedPrincipalFQN(principals []TablePrincipal, role string, inputFQN string) string {
+	if p := findPrincipal(principals, role, inputFQN); p != nil {
+		return p.PrincipalFQN
+	}
+	log.Printf("[WARN] Could not find resolved FQN for principal %q in .add response, using input value", inputFQN)
+	return inputFQN
+}
+
+// matchesPrincipal checks if an ADX principal matches the user-provided FQN.
+// ADX resolves e.g. "aaduser=user@example.com" to "aaduser=<guid>;<tenant-guid>",
+// so we must also check the PrincipalDisplayName which contains "(upn: user@example.com)".
+func matchesPrincipal(p TablePrincipal, inputFQN string) bool {
+	if inputFQN == "" {
+		return false
+	}
+	actualLower := strings.ToLower(p.PrincipalFQN)
+	inputLower := strings.ToLower(inputFQN)
+
+	// Exact match
+	if actualLower == inputLower {
+		return true
+	}
+
+	// Substring match on FQN (works for app IDs and GUIDs)
+	if strings.Contains(actualLower, inputLower) || strings.Contains(inputLower, actualLower) {
+		return true
+	}
+
+	// Extract the identifier after '=' (e.g., "aaduser=user@example.com" → "user@example.com")
+	// and check if it appears in PrincipalDisplayName (which contains the UPN for resolved users)
+	if parts := strings.SplitN(inputFQN, "=", 2); len(parts) == 2 {
+		identifier := strings.ToLower(parts[1])
+		if identifier != "" && p.PrincipalDisplayName != "" {
+			if strings.Contains(strings.ToLower(p.PrincipalDisplayName), identifier) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// principalKind returns the type prefix of a principal FQN, e.g. aaduser for aaduser=user@example.com
+func principalKind(fqn string) string {
+	return strings.ToLower(strings.SplitN(fqn, "=", 2)[0])
+}
+
+// suppressPrincipalFQNDiff suppresses diffs between user-provided FQN and ADX-resolved FQN.
+// After Read stores the resolved FQN (e.g. "aaduser=<guid>;<tenant>"), the next plan would
+// see a diff vs the config value (e.g. "aaduser=user@example.com"). We suppress this by
+// checking that both share the same principal type prefix.
+func suppressPrincipalFQNDiff(k, old, new string, d *schema.ResourceData) bool {
+	if old == "" || new == "" {
+		return old == new
+	}
+	if strings.EqualFold(old, new) {
+		return true
+	}
+	// Both must have the same principal type prefix (aaduser, aadapp, aadgroup, etc.)
+	oldParts := strings.SplitN(strings.ToLower(old), "=", 2)
+	newParts := strings.SplitN(strings.ToLower(new), "=", 2)
+	if len(oldParts) == 2 && len(newParts) == 2 && oldParts[0] == newParts[0] {
+		// Same principal type - suppress the diff since ADX resolves FQNs
+		return true
+	}
+	return false
+}
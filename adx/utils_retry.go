@@ -0,0 +1,195 @@
+package adx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/Azure/azure-kusto-go/kusto"
+	kustoerrors "github.com/Azure/azure-kusto-go/kusto/data/errors"
+	"github.com/Azure/azure-kusto-go/kusto/unsafe"
+)
+
+const (
+	defaultADXMaxRetries    = 5
+	defaultADXRetryMaxDelay = "60s"
+)
+
+// adxRetryBaseDelay is the delay before the first retry, it doubles with every further attempt
+var adxRetryBaseDelay = time.Second
+
+type adxErrorClass int
+
+const (
+	// adxErrorPermanent errors fail the command straight away
+	adxErrorPermanent adxErrorClass = iota
+	// adxErrorTransient errors are retried, e.g. 5xx responses, network errors or conflicting operations
+	adxErrorTransient
+	// adxErrorThrottled errors are retried with a longer backoff, the cluster rejected the command because of load
+	adxErrorThrottled
+)
+
+func (c adxErrorClass) String() string {
+	switch c {
+	case adxErrorTransient:
+		return "transient"
+	case adxErrorThrottled:
+		return "throttled"
+	default:
+		return "permanent"
+	}
+}
+
+var adxThrottledErrorMessages = []string{
+	"throttled",
+	"toomanyrequests",
+	"too many requests",
+}
+
+var adxTransientErrorMessages = []string{
+	"already in progress",
+	"another operation",
+	"operation is in progress",
+	"serviceisunavailable",
+	"service is unavailable",
+	"temporarily unavailable",
+	"connection reset",
+}
+
+// classifyADXError tells whether a failed command can be retried
+func classifyADXError(err error) adxErrorClass {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return adxErrorPermanent
+	}
+
+	// the message of an error response also contains the command text, only look at the error the cluster returned
+	message := strings.ToLower(err.Error())
+	var httpErr *kustoerrors.HttpError
+	if errors.As(err, &httpErr) {
+		if restErr := httpErr.UnmarshalREST(); restErr != nil {
+			message = strings.ToLower(fmt.Sprintf("%v", restErr))
+		}
+	}
+
+	for _, m := range adxThrottledErrorMessages {
+		if strings.Contains(message, m) {
+			return adxErrorThrottled
+		}
+	}
+
+	if httpErr != nil {
+		switch {
+		case httpErr.IsThrottled():
+			return adxErrorThrottled
+		case httpErr.StatusCode == http.StatusConflict || httpErr.StatusCode >= http.StatusInternalServerError:
+			return adxErrorTransient
+		}
+	}
+
+	for _, m := range adxTransientErrorMessages {
+		if strings.Contains(message, m) {
+			return adxErrorTransient
+		}
+	}
+
+	// errors raised before a response was received, such as network failures and timeouts
+	var kustoErr *kustoerrors.Error
+	if httpErr == nil && errors.As(err, &kustoErr) && (kustoErr.Kind == kustoerrors.KHTTPError || kustoErr.Kind == kustoerrors.KIO || kustoErr.Kind == kustoerrors.KTimeout) {
+		return adxErrorTransient
+	}
+
+	return adxErrorPermanent
+}
+
+// adxRetryDelay returns a random delay of up to base * 2^attempt, capped at maxDelay. Throttled commands
+// start from twice the base delay.
+func adxRetryDelay(attempt int, class adxErrorClass, maxDelay time.Duration) time.Duration {
+	ceiling := adxRetryBaseDelay << uint(attempt)
+	if class == adxErrorThrottled {
+		ceiling <<= 1
+	}
+	if ceiling <= 0 || ceiling > maxDelay {
+		ceiling = maxDelay
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(ceiling))) + 1
+}
+
+// isADXCommandNotRun tells whether an error proves the cluster did not run the command: it was throttled
+// or the connection was refused. Other transient errors may arrive after the command has run.
+func isADXCommandNotRun(err error, class adxErrorClass) bool {
+	return class == adxErrorThrottled || errors.Is(err, syscall.ECONNREFUSED) || strings.Contains(strings.ToLower(err.Error()), "connection refused")
+}
+
+// retryADXCommand runs fn until it succeeds, fails with a permanent error or the retries configured on
+// the provider are exhausted. Commands that are not idempotent are only retried when they did not run.
+func retryADXCommand[T any](ctx context.Context, meta interface{}, query string, idempotent bool, fn func() (T, error)) (T, error) {
+	maxRetries, maxDelay := 0, time.Duration(0)
+	if m, ok := meta.(*Meta); ok {
+		maxRetries, maxDelay = m.MaxRetries, m.RetryMaxDelay
+	}
+
+	for attempt := 0; ; attempt++ {
+		result, err := fn()
+		if err == nil {
+			return result, nil
+		}
+
+		class := classifyADXError(err)
+		if class == adxErrorPermanent || attempt >= maxRetries || (!idempotent && !isADXCommandNotRun(err, class)) {
+			return result, err
+		}
+
+		delay := adxRetryDelay(attempt, class, maxDelay)
+		log.Printf("[WARN] %s error executing adx command(%s), retrying in %s (attempt %d of %d): %+v", class, query, delay, attempt+1, maxRetries, err)
+		select {
+		case <-ctx.Done():
+			return result, fmt.Errorf("%+v (retry aborted: %+v)", err, ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+}
+
+// executeADXMgmt runs a control command, retrying it on throttling and transient errors
+func executeADXMgmt(ctx context.Context, meta interface{}, client *kusto.Client, databaseName string, query string, options ...kusto.MgmtOption) (*kusto.RowIterator, error) {
+	return executeADXMgmtWithRetry(ctx, meta, client, databaseName, query, true, options...)
+}
+
+// executeADXIngestMgmt runs a control command that ingests data, such as .set-or-append. Running it twice
+// ingests the data twice, so it is only retried when the cluster did not run it.
+func executeADXIngestMgmt(ctx context.Context, meta interface{}, client *kusto.Client, databaseName string, query string, options ...kusto.MgmtOption) (*kusto.RowIterator, error) {
+	return executeADXMgmtWithRetry(ctx, meta, client, databaseName, query, false, options...)
+}
+
+func executeADXMgmtWithRetry(ctx context.Context, meta interface{}, client *kusto.Client, databaseName string, query string, idempotent bool, options ...kusto.MgmtOption) (*kusto.RowIterator, error) {
+	kStmtOpts := kusto.UnsafeStmt(unsafe.Stmt{Add: true})
+	return retryADXCommand(ctx, meta, query, idempotent, func() (*kusto.RowIterator, error) {
+		release, err := acquireADXCommandSlot(ctx, meta, client.Endpoint())
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		return client.Mgmt(ctx, databaseName, kusto.NewStmt("", kStmtOpts).UnsafeAdd(query), options...)
+	})
+}
+
+// executeADXQuery runs a query, retrying it on throttling and transient errors
+func executeADXQuery(ctx context.Context, meta interface{}, client *kusto.Client, databaseName string, query string) (*kusto.RowIterator, error) {
+	kStmtOpts := kusto.UnsafeStmt(unsafe.Stmt{Add: true})
+	return retryADXCommand(ctx, meta, query, true, func() (*kusto.RowIterator, error) {
+		release, err := acquireADXCommandSlot(ctx, meta, client.Endpoint())
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		return client.Query(ctx, databaseName, kusto.NewStmt("", kStmtOpts).UnsafeAdd(query))
+	})
+}
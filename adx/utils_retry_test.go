@@ -0,0 +1,142 @@
+package adx
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	kustoerrors "github.com/Azure/azure-kusto-go/kusto/data/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func fakeKustoHTTPError(statusCode int, code string, message string) error {
+	body := fmt.Sprintf(`{"error": {"code": %q, "message": %q, "@permanent": false}}`, code, message)
+	return kustoerrors.HTTP(kustoerrors.OpMgmt, http.StatusText(statusCode), statusCode, io.NopCloser(strings.NewReader(body)), ".alter table T policy caching hot = 3d")
+}
+
+func TestUtils_classifyADXError(t *testing.T) {
+	cases := map[string]struct {
+		err      error
+		expected adxErrorClass
+	}{
+		"too many requests":     {fakeKustoHTTPError(http.StatusTooManyRequests, "TooManyRequests", "Too many requests"), adxErrorThrottled},
+		"throttled message":     {fakeKustoHTTPError(http.StatusBadRequest, "General_BadRequest", "Request is throttled"), adxErrorThrottled},
+		"service unavailable":   {fakeKustoHTTPError(http.StatusServiceUnavailable, "ServiceUnavailable", "Service is unavailable"), adxErrorTransient},
+		"conflict":              {fakeKustoHTTPError(http.StatusConflict, "Conflict", "Conflict"), adxErrorTransient},
+		"operation in progress": {fakeKustoHTTPError(http.StatusBadRequest, "General_BadRequest", "Operation 'AlterTable' is already in progress"), adxErrorTransient},
+		"syntax error":          {fakeKustoHTTPError(http.StatusBadRequest, "BadRequest_SyntaxError", "Syntax error"), adxErrorPermanent},
+		"entity not found":      {fakeKustoHTTPError(http.StatusBadRequest, "BadRequest_EntityNotFound", "Table 'T' was not found"), adxErrorPermanent},
+		"network error":         {kustoerrors.ES(kustoerrors.OpMgmt, kustoerrors.KHTTPError, "connection refused"), adxErrorTransient},
+		"client error":          {kustoerrors.ES(kustoerrors.OpMgmt, kustoerrors.KClientArgs, "invalid argument"), adxErrorPermanent},
+		"canceled":              {context.Canceled, adxErrorPermanent},
+		"other error":           {fmt.Errorf("unexpected"), adxErrorPermanent},
+	}
+	for name, c := range cases {
+		assert.Equal(t, c.expected, classifyADXError(c.err), name)
+	}
+}
+
+func TestUtils_adxRetryDelay(t *testing.T) {
+	for attempt := 0; attempt < 10; attempt++ {
+		delay := adxRetryDelay(attempt, adxErrorTransient, 5*time.Second)
+		assert.Greater(t, delay, time.Duration(0))
+		assert.LessOrEqual(t, delay, 5*time.Second)
+		assert.LessOrEqual(t, delay, adxRetryBaseDelay<<uint(attempt))
+	}
+	assert.LessOrEqual(t, adxRetryDelay(1, adxErrorThrottled, time.Minute), 4*adxRetryBaseDelay)
+	assert.Equal(t, time.Duration(0), adxRetryDelay(3, adxErrorThrottled, 0))
+}
+
+// retryTestMeta returns meta for the fake cluster that retries quickly
+func retryTestMeta(t *testing.T, f *fakeKusto, maxRetries int) *Meta {
+	previousBaseDelay := adxRetryBaseDelay
+	adxRetryBaseDelay = time.Millisecond
+	t.Cleanup(func() { adxRetryBaseDelay = previousBaseDelay })
+
+	meta := f.meta()
+	meta.MaxRetries = maxRetries
+	meta.RetryMaxDelay = 10 * time.Millisecond
+	return meta
+}
+
+func countCommands(f *fakeKusto, prefix string) int {
+	count := 0
+	for _, c := range f.Commands() {
+		if strings.HasPrefix(c.CSL, prefix) {
+			count++
+		}
+	}
+	return count
+}
+
+func TestFakeADXRetry_transient(t *testing.T) {
+	f := newFakeKusto(t)
+	f.database("fake_db").addTable("fake_table", "f1:string")
+	f.failNext(".alter table fake_table policy caching", 1, http.StatusTooManyRequests, "TooManyRequests")
+	f.failNext(".alter table fake_table policy caching", 1, http.StatusServiceUnavailable, "ServiceUnavailable")
+	r := newFakeResource(t, f, resourceADXTableCachingPolicy())
+	r.meta = retryTestMeta(t, f, 3)
+
+	state := r.apply(map[string]interface{}{
+		"database_name": "fake_db",
+		"table_name":    "fake_table",
+		"data_hot_span": "3d",
+	})
+	assert.Equal(t, "3d", state.Attributes["data_hot_span"])
+	assert.Equal(t, 3, countCommands(f, ".alter table fake_table policy caching"))
+}
+
+func TestFakeADXRetry_permanent(t *testing.T) {
+	f := newFakeKusto(t)
+	f.failNext(".show tables", 5, http.StatusBadRequest, "BadRequest_SyntaxError")
+	meta := retryTestMeta(t, f, 3)
+
+	_, err := queryADXMgmt(context.Background(), meta, meta.DefaultClusterConfig, "fake_db", ".show tables")
+	assert.ErrorContains(t, err, "BadRequest_SyntaxError")
+	assert.Equal(t, 1, countCommands(f, ".show tables"), "permanent errors should not be retried")
+}
+
+func TestFakeADXRetry_exhausted(t *testing.T) {
+	f := newFakeKusto(t)
+	f.failNext(".show tables", 5, http.StatusServiceUnavailable, "ServiceUnavailable")
+	meta := retryTestMeta(t, f, 2)
+
+	_, err := queryADXMgmt(context.Background(), meta, meta.DefaultClusterConfig, "fake_db", ".show tables")
+	assert.ErrorContains(t, err, "ServiceUnavailable")
+	assert.Equal(t, 3, countCommands(f, ".show tables"), "the command should be attempted once plus max_retries times")
+}
+
+func TestFakeADXRetry_canceled(t *testing.T) {
+	f := newFakeKusto(t)
+	f.failNext(".show tables", 5, http.StatusServiceUnavailable, "ServiceUnavailable")
+	meta := retryTestMeta(t, f, 5)
+	meta.RetryMaxDelay = time.Minute
+	adxRetryBaseDelay = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := queryADXMgmt(ctx, meta, meta.DefaultClusterConfig, "fake_db", ".show tables")
+	assert.ErrorContains(t, err, "retry aborted")
+	assert.Equal(t, 1, countCommands(f, ".show tables"))
+}
+
+func TestFakeADXRetry_notIdempotent(t *testing.T) {
+	f := newFakeKusto(t)
+	f.failNext(".show tables", 1, http.StatusServiceUnavailable, "ServiceUnavailable")
+	meta := retryTestMeta(t, f, 3)
+	client, err := getADXClient(meta, meta.DefaultClusterConfig)
+	assert.NoError(t, err)
+
+	_, err = executeADXIngestMgmt(context.Background(), meta, client, "fake_db", ".show tables details")
+	assert.ErrorContains(t, err, "ServiceUnavailable")
+	assert.Equal(t, 1, countCommands(f, ".show tables"), "the command may have run, it should not be retried")
+
+	f.failNext(".show tables", 2, http.StatusTooManyRequests, "TooManyRequests")
+	_, err = executeADXIngestMgmt(context.Background(), meta, client, "fake_db", ".show tables details")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, countCommands(f, ".show tables"), "throttled commands did not run and should be retried")
+}
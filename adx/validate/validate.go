@@ -1,8 +1,10 @@
 package validate
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"regexp"
+	"time"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/go-uuid"
@@ -116,3 +118,33 @@ func StringIsJSON(i interface{}, k cty.Path) diag.Diagnostics {
 
 	return nil
 }
+
+func StringIsBase64(i interface{}, k cty.Path) diag.Diagnostics {
+	v, ok := i.(string)
+	if !ok {
+		return diag.Errorf("expected type of %q to be string", k)
+	}
+
+	if _, err := base64.StdEncoding.DecodeString(v); err != nil {
+		return diag.Errorf("expected %q to be a base64 string: %s", k, err)
+	}
+
+	return nil
+}
+
+func StringIsDuration(i interface{}, k cty.Path) diag.Diagnostics {
+	v, ok := i.(string)
+	if !ok {
+		return diag.Errorf("expected type of %q to be string", k)
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return diag.Errorf("expected %q to be a duration such as 30s or 2m: %s", k, err)
+	}
+	if d < 0 {
+		return diag.Errorf("expected %q to not be negative, got: %v", k, v)
+	}
+
+	return nil
+}